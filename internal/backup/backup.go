@@ -0,0 +1,143 @@
+package backup
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/hulk510/readme-gen/internal/config"
+	"github.com/hulk510/readme-gen/internal/fileutil"
+)
+
+// Dir is the backup directory relative to the project root
+const Dir = config.WorkDir + "/backups"
+
+// timestampFormat names backup directories so that they sort chronologically
+const timestampFormat = "20060102-150405.000000000"
+
+// Save copies the file at path (relative to root) into the backup called
+// name, or into a new timestamped backup when name is empty, and returns
+// the backup's name. Files already in the backup are kept, so a backup
+// holds the content from before its first write. Save returns name without
+// error if the file does not exist, since there is nothing to lose.
+func Save(root, name, path string) (string, error) {
+	src := filepath.Join(root, path)
+	info, err := os.Stat(src)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return name, nil
+		}
+		return name, err
+	}
+
+	content, err := os.ReadFile(src)
+	if err != nil {
+		return name, err
+	}
+
+	if err := ensureDir(root); err != nil {
+		return name, err
+	}
+
+	if name == "" {
+		name = time.Now().Format(timestampFormat)
+	}
+	dst := filepath.Join(root, Dir, name, path)
+	if _, err := os.Stat(dst); err == nil {
+		return name, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return name, err
+	}
+	if err := os.WriteFile(dst, content, info.Mode().Perm()); err != nil {
+		return name, err
+	}
+
+	return name, nil
+}
+
+// List returns the names of all backups, oldest first
+func List(root string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(root, Dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Restore copies the files of the latest backup back to their original
+// locations and removes the backup. It returns the backup's name and the
+// restored paths relative to root.
+func Restore(root string) (string, []string, error) {
+	names, err := List(root)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(names) == 0 {
+		return "", nil, fmt.Errorf("no backups found in %s", Dir)
+	}
+
+	name := names[len(names)-1]
+	backupRoot := filepath.Join(root, Dir, name)
+
+	var restored []string
+	err = filepath.WalkDir(backupRoot, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(backupRoot, p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		if err := fileutil.WriteFile(dst, content, info.Mode().Perm()); err != nil {
+			return err
+		}
+		restored = append(restored, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return name, restored, os.RemoveAll(backupRoot)
+}
+
+// ensureDir creates the backup directory with a .gitignore so that
+// backups are never committed
+func ensureDir(root string) error {
+	dir := filepath.Join(root, Dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	gitignore := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(gitignore); err == nil {
+		return nil
+	}
+	return os.WriteFile(gitignore, []byte("*\n"), 0644)
+}
@@ -0,0 +1,105 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveAndRestore(t *testing.T) {
+	root := t.TempDir()
+	readme := filepath.Join(root, "README.md")
+
+	if err := os.WriteFile(readme, []byte("first"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Save(root, "", "README.md"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := os.WriteFile(readme, []byte("second"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Save(root, "", "README.md"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := os.WriteFile(readme, []byte("third"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := List(root)
+	if err != nil || len(names) != 2 {
+		t.Fatalf("List() = %v, %v; want 2 backups", names, err)
+	}
+
+	for _, want := range []string{"second", "first"} {
+		_, restored, err := Restore(root)
+		if err != nil {
+			t.Fatalf("Restore() error = %v", err)
+		}
+		if len(restored) != 1 || restored[0] != "README.md" {
+			t.Errorf("Restore() restored = %v", restored)
+		}
+		content, _ := os.ReadFile(readme)
+		if string(content) != want {
+			t.Errorf("README.md = %q, want %q", content, want)
+		}
+	}
+
+	info, err := os.Stat(readme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	if _, _, err := Restore(root); err == nil {
+		t.Error("Restore() without backups should fail")
+	}
+	if _, err := os.Stat(filepath.Join(root, Dir, ".gitignore")); err != nil {
+		t.Errorf("backup directory should contain a .gitignore: %v", err)
+	}
+}
+
+func TestSave_MissingFile(t *testing.T) {
+	root := t.TempDir()
+
+	name, err := Save(root, "", "README.md")
+	if err != nil || name != "" {
+		t.Errorf("Save() = %q, %v; want no backup", name, err)
+	}
+}
+
+func TestSave_Named(t *testing.T) {
+	root := t.TempDir()
+	for path, content := range map[string]string{"README.md": "en", "README.ja.md": "ja"} {
+		if err := os.WriteFile(filepath.Join(root, path), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	name, err := Save(root, "", "README.md")
+	if err != nil || name == "" {
+		t.Fatalf("Save() = %q, %v", name, err)
+	}
+	// A file saved twice into one backup keeps its first content
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"README.md", "README.ja.md"} {
+		if got, err := Save(root, name, path); err != nil || got != name {
+			t.Fatalf("Save(%s) = %q, %v; want %q", path, got, err, name)
+		}
+	}
+
+	if names, err := List(root); err != nil || len(names) != 1 {
+		t.Fatalf("List() = %v, %v; want one backup", names, err)
+	}
+	_, restored, err := Restore(root)
+	if err != nil || len(restored) != 2 {
+		t.Fatalf("Restore() = %v, %v; want both files", restored, err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(root, "README.md")); string(content) != "en" {
+		t.Errorf("README.md = %q, want en", content)
+	}
+}
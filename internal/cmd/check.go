@@ -4,11 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/hulk510/readme-gen/internal/config"
 	"github.com/hulk510/readme-gen/internal/i18n"
 	"github.com/hulk510/readme-gen/internal/marker"
 	"github.com/hulk510/readme-gen/internal/scanner"
 	"github.com/hulk510/readme-gen/internal/ui"
+	ignore "github.com/sabhiram/go-gitignore"
 	"github.com/spf13/cobra"
 )
 
@@ -18,16 +21,45 @@ var exitFunc = os.Exit
 // ErrOutOfSync is returned when structure is out of sync (for testing)
 var ErrOutOfSync = errors.New("structure out of sync")
 
+// ErrMissingDescriptions is returned when directories lack descriptions (for testing)
+var ErrMissingDescriptions = errors.New("directories missing descriptions")
+
+var (
+	requireDescriptions bool
+	descriptionDepth    int
+	descriptionIgnore   []string
+	minCoverage         float64
+)
+
 var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Check if README structure is up to date",
-	Long:  `Verify that the directory structure in README.md matches the current state. Exits with code 1 if out of sync.`,
-	RunE:  runCheck,
+	Long: `Verify that the directory structure in README.md matches the current state. Exits with code 1 if out of sync.
+
+If the project has a README.md.tmpl, README.md must also match what 'readme-gen sync' generates.`,
+	RunE: runCheck,
+}
+
+func init() {
+	checkCmd.Flags().BoolVar(&requireDescriptions, "require-descriptions", false, "Fail when a directory in the structure has no comment")
+	checkCmd.Flags().IntVar(&descriptionDepth, "description-depth", 0, "Only require descriptions up to this depth (0 = all, 1 = top level)")
+	checkCmd.Flags().StringSliceVar(&descriptionIgnore, "description-ignore", nil, "Paths that do not need descriptions (gitignore syntax)")
+	checkCmd.Flags().Float64Var(&minCoverage, "min-coverage", 0, "Required percentage of described directories (implies --require-descriptions)")
 }
 
 func runCheck(cmd *cobra.Command, args []string) error {
 	msg := i18n.Get()
 
+	// READMEs generated from a template must match what sync would write
+	readmes, err := renderReadmes(".")
+	if err != nil {
+		return err
+	}
+	if len(readmes) > 0 && !checkReadmes(msg, readmes) {
+		exitFunc(1)
+		return ErrOutOfSync
+	}
+
 	// Read current README
 	content, err := os.ReadFile("README.md")
 	if err != nil {
@@ -35,32 +67,126 @@ func runCheck(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("%s", msg.ReadmeNotFound)
 	}
 
+	cfg, err := config.Load(".")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	target := structureTarget(cfg)
+
+	// Generated sections (badges, ...) must match what update would write
+	sectionsInSync, err := checkSections(msg, cfg)
+	if err != nil {
+		return err
+	}
+	if !sectionsInSync {
+		exitFunc(1)
+		return ErrOutOfSync
+	}
+
 	// Extract current structure from README
-	readmeStructure, found := marker.Extract(string(content))
+	readmeStructure, firstLine, found := target.ExtractWithLine(string(content))
 	if !found {
-		fmt.Println(ui.Warn(msg.NoMarkersFound))
-		fmt.Println(ui.Info(msg.AddMarkersHint))
+		warnNoStructure(msg, target)
 		return nil
 	}
 
 	// Scan current directory
-	currentStructure, err := scanner.Scan(".", scanner.DefaultExcludes())
+	currentStructure, err := scanner.ScanWithMatcher(".", scanner.NewMatcher(".", cfg))
 	if err != nil {
 		return fmt.Errorf("failed to scan directory: %w", err)
 	}
 
 	// Compare (strip comments from README structure for comparison)
-	if marker.StripComments(readmeStructure) == currentStructure {
-		fmt.Println(ui.Check(msg.StructureUpToDate))
-		return nil
+	if marker.StripComments(readmeStructure) != currentStructure {
+		// Out of sync
+		fmt.Println(ui.Warn(msg.StructureOutOfSync))
+		reportCommentIssues(msg, marker.CheckComments(readmeStructure, currentStructure), firstLine)
+		fmt.Println()
+		fmt.Println(ui.Info(msg.RunUpdateHint))
+
+		// Exit with error for CI
+		exitFunc(1)
+		return ErrOutOfSync
+	}
+	fmt.Println(ui.Check(msg.StructureUpToDate))
+
+	if requireDescriptions || minCoverage > 0 {
+		if !checkDescriptions(msg, readmeStructure, firstLine) {
+			exitFunc(1)
+			return ErrMissingDescriptions
+		}
+	}
+
+	return nil
+}
+
+// reportCommentIssues prints stale, moved and duplicate entries with their
+// line numbers in README.md
+func reportCommentIssues(msg i18n.Messages, issues []marker.Issue, firstLine int) {
+	if len(issues) == 0 {
+		return
 	}
 
-	// Out of sync
-	fmt.Println(ui.Warn(msg.StructureOutOfSync))
 	fmt.Println()
-	fmt.Println(ui.Info(msg.RunUpdateHint))
+	for _, issue := range issues {
+		line := firstLine + issue.Entry.Line
+		path := issue.Entry.DisplayPath()
+		switch issue.Kind {
+		case marker.IssueStale:
+			fmt.Printf("  README.md:%d  %s\n", line, fmt.Sprintf(msg.CommentStale, path, issue.Entry.Comment))
+		case marker.IssueMoved:
+			fmt.Printf("  README.md:%d  %s\n", line, fmt.Sprintf(msg.CommentMoved, path, firstLine+issue.OtherLine))
+		case marker.IssueDuplicate:
+			fmt.Printf("  README.md:%d  %s\n", line, fmt.Sprintf(msg.DuplicateEntry, path, firstLine+issue.OtherLine))
+		}
+	}
+}
+
+// checkDescriptions reports directories without an inline comment and
+// returns whether the description requirements are met
+func checkDescriptions(msg i18n.Messages, structure string, firstLine int) bool {
+	var ignored *ignore.GitIgnore
+	if len(descriptionIgnore) > 0 {
+		ignored = ignore.CompileIgnoreLines(descriptionIgnore...)
+	}
 
-	// Exit with error for CI
-	exitFunc(1)
-	return ErrOutOfSync
+	var missing []marker.Entry
+	total := 0
+	for _, e := range marker.ParseTree(structure) {
+		if !e.IsDir {
+			continue
+		}
+		if descriptionDepth > 0 && strings.Count(e.Path, "/")+1 > descriptionDepth {
+			continue
+		}
+		if ignored != nil && ignored.MatchesPath(e.Path+"/") {
+			continue
+		}
+		total++
+		if e.Comment == "" {
+			missing = append(missing, e)
+		}
+	}
+
+	if len(missing) == 0 {
+		fmt.Println(ui.Check(msg.AllDirectoriesDescribed))
+		return true
+	}
+
+	coverage := 100 * float64(total-len(missing)) / float64(total)
+
+	fmt.Println(ui.Warn(fmt.Sprintf(msg.DescriptionsMissing, len(missing))))
+	for _, e := range missing {
+		fmt.Printf("  README.md:%d  %s/\n", firstLine+e.Line, e.Path)
+	}
+	fmt.Println()
+	fmt.Println(ui.Info(fmt.Sprintf(msg.DescriptionCoverage, coverage, total-len(missing), total)))
+
+	if minCoverage > 0 {
+		if coverage >= minCoverage {
+			return true
+		}
+		fmt.Println(ui.Warn(fmt.Sprintf(msg.CoverageBelowMinimum, minCoverage)))
+	}
+	return false
 }
@@ -5,6 +5,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/hulk510/readme-gen/internal/backup"
+	"github.com/hulk510/readme-gen/internal/i18n"
 )
 
 // setupTestDir creates a temporary directory with test files and returns cleanup function
@@ -28,7 +31,11 @@ func setupTestDir(t *testing.T) (string, func()) {
 		t.Fatalf("failed to change to temp dir: %v", err)
 	}
 
+	// Each test starts a new backup, as each command invocation does
+	backupName = ""
+
 	cleanup := func() {
+		backupName = ""
 		os.Chdir(origDir)
 		os.RemoveAll(dir)
 	}
@@ -109,6 +116,38 @@ old structure
 	}
 }
 
+func TestRunStructure_UpdateFmt(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	createTestFile(t, "cmd/main.go", "package main")
+	createTestFile(t, "internal/x.go", "package internal")
+	createTestFile(t, ".readme-gen.yaml", "descriptions:\n  cmd: CLI entry point\n  internal: \"Internal    packages\"\n")
+	createTestFile(t, "README.md", "# Test Project\n\n<!-- readme-gen:structure:start -->\n```\n```\n<!-- readme-gen:structure:end -->\n")
+
+	updateFlag, formatFlag = true, true
+	defer func() { updateFlag, formatFlag = false, false }()
+
+	if err := runStructure(nil, nil); err != nil {
+		t.Fatalf("runStructure() error = %v", err)
+	}
+	content := readTestFile(t, "README.md")
+	if !strings.Contains(content, "├── cmd/       # CLI entry point\n└── internal/  # Internal packages\n") {
+		t.Errorf("expected normalized comments, got:\n%s", content)
+	}
+
+	// The result needs no further formatting
+	fmtCheck = true
+	defer func() { fmtCheck = false }()
+	origExitFunc := exitFunc
+	exitFunc = func(code int) { t.Errorf("unexpected exit with code %d", code) }
+	defer func() { exitFunc = origExitFunc }()
+	if err := runFmt(nil, nil); err != nil {
+		t.Errorf("runFmt() --check error = %v", err)
+	}
+}
+
 func TestRunStructure_NoReadme(t *testing.T) {
 	_, cleanup := setupTestDir(t)
 	defer cleanup()
@@ -290,3 +329,671 @@ func TestContains(t *testing.T) {
 		}
 	}
 }
+
+func TestRunStructure_UpdateWithConfigDescriptions(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+
+	createTestFile(t, "cmd/app/main.go", "package main")
+	createTestFile(t, "internal/ui/ui.go", "package ui")
+	createTestFile(t, ".readme-gen.yaml", `descriptions:
+  cmd: CLI entry point
+  internal/ui:
+    default: Terminal UI
+    ja: ターミナルUI
+`)
+	createTestFile(t, "README.md", "# Test\n\n<!-- readme-gen:structure:start -->\n```\n```\n<!-- readme-gen:structure:end -->\n")
+
+	updateFlag = true
+	defer func() { updateFlag = false }()
+
+	if err := runStructure(nil, nil); err != nil {
+		t.Fatalf("runStructure() error = %v", err)
+	}
+
+	content := readTestFile(t, "README.md")
+	if !strings.Contains(content, "├── cmd/       # CLI entry point") {
+		t.Errorf("expected aligned cmd comment, got:\n%s", content)
+	}
+	if !strings.Contains(content, "    └── ui/    # Terminal UI") {
+		t.Errorf("expected aligned ui comment, got:\n%s", content)
+	}
+}
+
+func TestRunDescriptionsImport(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+
+	createTestFile(t, ".readme-gen.yaml", "structure:\n  max_depth: 2\ndescriptions:\n  cmd: Kept\n")
+	createTestFile(t, "README.md", `# Test
+
+<!-- readme-gen:structure:start -->
+`+"```"+`
+├── cmd/           # CLI entry point
+│   └── app/
+└── internal/      # Internal packages
+    └── ui/        # Terminal UI
+`+"```"+`
+<!-- readme-gen:structure:end -->
+`)
+
+	importFile = "README.md"
+	importLocale = ""
+	importOverwrite = false
+
+	if err := runDescriptionsImport(nil, nil); err != nil {
+		t.Fatalf("runDescriptionsImport() error = %v", err)
+	}
+
+	content := readTestFile(t, ".readme-gen.yaml")
+	for _, want := range []string{"max_depth: 2", "cmd: Kept", "internal: Internal packages", "internal/ui: Terminal UI"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected config to contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestRunCheck_RequireDescriptions(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+
+	exitCode := 0
+	origExitFunc := exitFunc
+	exitFunc = func(code int) { exitCode = code }
+	defer func() { exitFunc = origExitFunc }()
+	defer func() {
+		requireDescriptions = false
+		descriptionDepth = 0
+		descriptionIgnore = nil
+		minCoverage = 0
+	}()
+
+	createTestFile(t, "cmd/app/main.go", "package main")
+	createTestFile(t, "internal/ui/ui.go", "package ui")
+	createTestFile(t, "tools/gen.go", "package tools")
+	createTestFile(t, "README.md", `# Test Project
+
+<!-- readme-gen:structure:start -->
+`+"```"+`
+├── cmd/        # CLI entry point
+│   └── app/
+├── internal/   # Internal packages
+│   └── ui/
+└── tools/
+`+"```"+`
+<!-- readme-gen:structure:end -->
+`)
+
+	tests := []struct {
+		name     string
+		depth    int
+		ignore   []string
+		coverage float64
+		wantErr  error
+	}{
+		{"all directories", 0, nil, 0, ErrMissingDescriptions},
+		{"top level only", 1, nil, 0, ErrMissingDescriptions},
+		{"top level with ignore", 1, []string{"tools"}, 0, nil},
+		{"coverage met", 0, nil, 40, nil},
+		{"coverage not met", 0, nil, 50, ErrMissingDescriptions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exitCode = 0
+			requireDescriptions = tt.coverage == 0
+			descriptionDepth = tt.depth
+			descriptionIgnore = tt.ignore
+			minCoverage = tt.coverage
+
+			err := runCheck(nil, nil)
+			if err != tt.wantErr {
+				t.Errorf("runCheck() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && exitCode != 1 {
+				t.Errorf("exit code should be 1, got: %d", exitCode)
+			}
+		})
+	}
+}
+
+func TestRunFmt(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+
+	createTestFile(t, "README.md", `# Test Project
+
+<!-- readme-gen:structure:start -->
+`+"```"+`
+├── cmd/ # CLI entry point
+└── internal/	#  Internal packages
+`+"```"+`
+<!-- readme-gen:structure:end -->
+`)
+
+	fmtWidth = 0
+	fmtCheck = false
+
+	if err := runFmt(nil, nil); err != nil {
+		t.Fatalf("runFmt() error = %v", err)
+	}
+
+	content := readTestFile(t, "README.md")
+	if !strings.Contains(content, "├── cmd/       # CLI entry point\n└── internal/  # Internal packages\n") {
+		t.Errorf("expected aligned comments, got:\n%s", content)
+	}
+
+	// Already formatted: --check passes without exiting
+	fmtCheck = true
+	defer func() { fmtCheck = false }()
+	origExitFunc := exitFunc
+	exitFunc = func(code int) { t.Errorf("unexpected exit with code %d", code) }
+	defer func() { exitFunc = origExitFunc }()
+
+	if err := runFmt(nil, nil); err != nil {
+		t.Errorf("runFmt() --check error = %v", err)
+	}
+}
+
+func TestRunStructure_Insert(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+
+	createTestFile(t, "src/main.go", "package main")
+	createTestFile(t, "README.md", "# Legacy\n\nIntro.\n\n## Structure\n\n```\nsrc/ - sources\n```\n\n## License\n\nMIT\n")
+
+	insertFlag = true
+	insertPosition = "end"
+	defer func() { insertFlag = false }()
+
+	if err := runStructure(nil, nil); err != nil {
+		t.Fatalf("runStructure() error = %v", err)
+	}
+
+	content := readTestFile(t, "README.md")
+	want := "# Legacy\n\nIntro.\n\n## Structure\n\n<!-- readme-gen:structure:start -->\n```\n└── src/\n```\n<!-- readme-gen:structure:end -->\n\n## License\n\nMIT\n"
+	if content != want {
+		t.Errorf("unexpected README:\n%s", content)
+	}
+}
+
+func TestRunCheck_HeadingMode(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+
+	exitCalled := false
+	origExitFunc := exitFunc
+	exitFunc = func(code int) { exitCalled = true }
+	defer func() { exitFunc = origExitFunc }()
+
+	createTestFile(t, "src/main.go", "package main")
+	createTestFile(t, "newdir/file.go", "package newdir")
+	createTestFile(t, ".readme-gen.yaml", "structure:\n  heading: \"## Structure\"\n")
+	createTestFile(t, "README.md", "# Test\n\n## Structure\n\n```\n└── src/  # Sources\n```\n\n## Usage\n\n```bash\nrun\n```\n")
+
+	if err := runCheck(nil, nil); err != ErrOutOfSync {
+		t.Fatalf("runCheck() should return ErrOutOfSync, got: %v", err)
+	}
+	if !exitCalled {
+		t.Error("exitFunc should be called")
+	}
+
+	updateFlag = true
+	defer func() { updateFlag = false }()
+	if err := runStructure(nil, nil); err != nil {
+		t.Fatalf("runStructure() error = %v", err)
+	}
+
+	content := readTestFile(t, "README.md")
+	if !strings.Contains(content, "## Structure\n\n```\n├── newdir/\n└── src/\n```\n\n## Usage\n\n```bash\nrun\n```\n") {
+		t.Errorf("unexpected README:\n%s", content)
+	}
+	if strings.Contains(content, "readme-gen:structure") {
+		t.Error("expected no markers in heading mode")
+	}
+
+	exitCalled = false
+	if err := runCheck(nil, nil); err != nil {
+		t.Errorf("runCheck() after update error = %v", err)
+	}
+}
+
+func TestRunInit_Adopt(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+
+	createTestFile(t, "go.mod", "module github.com/test/project\n\ngo 1.21")
+	createTestFile(t, "src/main.go", "package main")
+	createTestFile(t, "README.md", "# Project\n\nHand-written intro.\n\n## Usage\n\nMy usage.\n")
+
+	nonInteractive = true
+	templateFlag = "oss"
+	noSkills = true
+	withSkills = false
+	noAI = true
+	adoptFlag = true
+	defer func() { adoptFlag = false }()
+
+	if err := runInit(nil, nil); err != nil {
+		t.Fatalf("runInit() error = %v", err)
+	}
+
+	content := readTestFile(t, "README.md")
+	if !strings.HasPrefix(content, "# Project\n\nHand-written intro.\n\n## Structure\n") {
+		t.Errorf("expected existing intro to be kept, got:\n%s", content)
+	}
+	if !strings.Contains(content, "## Usage\n\nMy usage.\n") {
+		t.Errorf("expected existing usage to be kept, got:\n%s", content)
+	}
+	for _, want := range []string{"readme-gen:structure:start", "## Installation", "## License"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected README to contain %q, got:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "[command]") {
+		t.Errorf("expected template usage section not to be added, got:\n%s", content)
+	}
+}
+
+func TestRunInit_AdoptHeadingMode(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+
+	createTestFile(t, "go.mod", "module github.com/test/project\n\ngo 1.21")
+	createTestFile(t, "src/main.go", "package main")
+	createTestFile(t, ".readme-gen.yaml", "structure:\n  heading: \"## Structure\"\n")
+	createTestFile(t, "README.md", "# Project\n\nHand-written intro.\n\n## Usage\n\nMy usage.\n")
+
+	nonInteractive = true
+	templateFlag = "oss"
+	noSkills = true
+	withSkills = false
+	noAI = true
+	adoptFlag = true
+	defer func() { adoptFlag = false }()
+
+	if err := runInit(nil, nil); err != nil {
+		t.Fatalf("runInit() error = %v", err)
+	}
+
+	content := readTestFile(t, "README.md")
+	if !strings.Contains(content, "## Structure\n\n```\n") {
+		t.Errorf("expected structure heading and code block, got:\n%s", content)
+	}
+	if strings.Contains(content, "readme-gen:structure") {
+		t.Errorf("expected no structure markers in heading mode, got:\n%s", content)
+	}
+	if err := runCheck(nil, nil); err != nil {
+		t.Errorf("runCheck() after adopt error = %v", err)
+	}
+}
+
+func TestRunInit_RefusesOverwriteWithoutForce(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+
+	exitCalled := false
+	origExitFunc := exitFunc
+	exitFunc = func(code int) { exitCalled = true }
+	defer func() { exitFunc = origExitFunc }()
+
+	original := "# Hand-written\n\nDo not lose me.\n"
+	createTestFile(t, "README.md", original)
+
+	nonInteractive = true
+	templateFlag = "oss"
+	noSkills = true
+	noAI = true
+
+	if err := runInit(nil, nil); err != ErrReadmeExists {
+		t.Fatalf("runInit() should return ErrReadmeExists, got: %v", err)
+	}
+	if !exitCalled {
+		t.Error("exitFunc should be called")
+	}
+	if content := readTestFile(t, "README.md"); content != original {
+		t.Errorf("README.md should be untouched, got:\n%s", content)
+	}
+
+	forceFlag = true
+	defer func() { forceFlag = false }()
+	if err := runInit(nil, nil); err != nil {
+		t.Fatalf("runInit() with --force error = %v", err)
+	}
+	if content := readTestFile(t, "README.md"); content == original {
+		t.Fatal("README.md should be overwritten with --force")
+	}
+
+	if err := runRestore(nil, nil); err != nil {
+		t.Fatalf("runRestore() error = %v", err)
+	}
+	if content := readTestFile(t, "README.md"); content != original {
+		t.Errorf("README.md should be restored, got:\n%s", content)
+	}
+}
+
+func TestGenerateWithAI_RollsBackOnFailure(t *testing.T) {
+	dir, cleanup := setupTestDir(t)
+	defer cleanup()
+
+	// Fake claude that truncates README.md and fails
+	bin := filepath.Join(dir, "bin")
+	createTestFile(t, "bin/claude", "#!/bin/sh\nprintf 'partial' > README.md\nexit 1\n")
+	if err := os.Chmod(filepath.Join(bin, "claude"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	original := "# Test\n\nOriginal content.\n"
+	createTestFile(t, "README.md", original)
+
+	if err := generateWithAI(i18n.Get()); err == nil {
+		t.Fatal("generateWithAI() should fail")
+	}
+	if content := readTestFile(t, "README.md"); content != original {
+		t.Errorf("README.md should be rolled back, got: %q", content)
+	}
+}
+
+func TestGenerateWithAI_NoBackupWithoutClaude(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+	t.Setenv("PATH", t.TempDir())
+
+	createTestFile(t, "README.md", "# Test\n")
+
+	if err := generateWithAI(i18n.Get()); err == nil {
+		t.Fatal("generateWithAI() should fail without claude")
+	}
+	if names, err := backup.List("."); err != nil || len(names) != 0 {
+		t.Errorf("backup.List() = %v, %v; want no backup", names, err)
+	}
+}
+
+func TestRunInit_ProjectTemplate(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	createTestFile(t, "go.mod", "module github.com/test/project\n\ngo 1.21")
+	createTestFile(t, "src/main.go", "package main")
+	createTestFile(t, ".readme-gen/templates/company.md.tmpl", "# {{.ProjectName}}\n\nCompany layout.\n\n{{.Structure}}\n")
+
+	nonInteractive = true
+	templateFlag = "company"
+	noSkills = true
+	noAI = true
+	defer func() { templateFlag = "" }()
+
+	if err := runInit(nil, nil); err != nil {
+		t.Fatalf("runInit() error = %v", err)
+	}
+
+	content := readTestFile(t, "README.md")
+	if !strings.Contains(content, "Company layout.") {
+		t.Errorf("README should use the project template, got:\n%s", content)
+	}
+	if !strings.Contains(content, "├── .readme-gen/\n│   └── templates/") || !strings.Contains(content, "└── src/") || strings.Contains(content, "backups/") {
+		t.Errorf("unexpected structure:\n%s", content)
+	}
+}
+
+func TestRunTemplateEjectAndLint(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	exitCalled := false
+	origExitFunc := exitFunc
+	exitFunc = func(code int) { exitCalled = true }
+	defer func() { exitFunc = origExitFunc }()
+
+	if err := runTemplateEject(nil, []string{"oss"}); err != nil {
+		t.Fatalf("runTemplateEject() error = %v", err)
+	}
+	for _, file := range []string{"oss.md.tmpl", "oss_ja.md.tmpl"} {
+		if _, err := os.Stat(filepath.Join(".readme-gen", "templates", file)); err != nil {
+			t.Errorf("%s should be ejected: %v", file, err)
+		}
+	}
+	if err := runTemplateEject(nil, []string{"oss"}); err == nil {
+		t.Error("runTemplateEject() should refuse to overwrite without --force")
+	}
+
+	if err := runTemplateLint(nil, nil); err != nil {
+		t.Fatalf("runTemplateLint() error = %v", err)
+	}
+
+	createTestFile(t, ".readme-gen/templates/broken.md.tmpl", "# {{.Name}}\n")
+	if err := runTemplateLint(nil, nil); err != ErrTemplateLint {
+		t.Errorf("runTemplateLint() should return ErrTemplateLint, got: %v", err)
+	}
+	if !exitCalled {
+		t.Error("exitFunc should be called")
+	}
+}
+
+func TestRunSync(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	exitCalled := false
+	origExitFunc := exitFunc
+	exitFunc = func(code int) { exitCalled = true }
+	defer func() { exitFunc = origExitFunc }()
+
+	createTestFile(t, "go.mod", "module github.com/test/project\n\ngo 1.21")
+	createTestFile(t, "src/main.go", "package main")
+	createTestFile(t, "README.md.tmpl", "# {{title .ProjectName}}\n\n{{.Structure}}\n")
+	createTestFile(t, "README.ja.md.tmpl", "{{/* extends: oss */}}\n")
+
+	if err := runCheck(nil, nil); err != ErrOutOfSync {
+		t.Fatalf("runCheck() before sync should return ErrOutOfSync, got: %v", err)
+	}
+	if !exitCalled {
+		t.Error("exitFunc should be called")
+	}
+
+	if err := runSync(nil, nil); err != nil {
+		t.Fatalf("runSync() error = %v", err)
+	}
+	want := "# Project\n\n<!-- readme-gen:structure:start -->\n```\n└── src/\n```\n<!-- readme-gen:structure:end -->\n"
+	if content := readTestFile(t, "README.md"); content != want {
+		t.Errorf("README.md =\n%s\nwant\n%s", content, want)
+	}
+	if content := readTestFile(t, "README.ja.md"); !strings.Contains(content, "## インストール") {
+		t.Errorf("README.ja.md should be rendered in Japanese, got:\n%s", content)
+	}
+
+	exitCalled = false
+	if err := runCheck(nil, nil); err != nil {
+		t.Errorf("runCheck() after sync error = %v", err)
+	}
+
+	// A hand edit to the generated README is reported
+	createTestFile(t, "README.md", want+"\nEdited by hand\n")
+	if err := runCheck(nil, nil); err != ErrOutOfSync {
+		t.Errorf("runCheck() after edit should return ErrOutOfSync, got: %v", err)
+	}
+}
+
+func TestRunUpdate(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	exitCalled := false
+	origExitFunc := exitFunc
+	exitFunc = func(code int) { exitCalled = true }
+	defer func() { exitFunc = origExitFunc }()
+
+	createTestFile(t, "go.mod", "module github.com/test/project\n\ngo 1.21")
+	createTestFile(t, "LICENSE", "MIT License\n\nPermission is hereby granted, free of charge, to any person")
+	createTestFile(t, "src/main.go", "package main")
+	readme := "# Project\n\n<!-- readme-gen:badges:start -->\n<!-- readme-gen:badges:end -->\n\n" +
+		"<!-- readme-gen:structure:start -->\n```\n└── src/\n```\n<!-- readme-gen:structure:end -->\n"
+	createTestFile(t, "README.md", readme)
+
+	if err := runCheck(nil, nil); err != ErrOutOfSync {
+		t.Fatalf("runCheck() before update should return ErrOutOfSync, got: %v", err)
+	}
+	if !exitCalled {
+		t.Error("exitFunc should be called")
+	}
+
+	if err := runUpdate(nil, nil); err != nil {
+		t.Fatalf("runUpdate() error = %v", err)
+	}
+	content := readTestFile(t, "README.md")
+	for _, want := range []string{
+		"<!-- readme-gen:badges:start -->\n[![License: MIT](https://img.shields.io/badge/license-MIT-blue)](LICENSE)\n",
+		"[![Go Report Card](https://goreportcard.com/badge/github.com/test/project)](https://goreportcard.com/report/github.com/test/project)\n<!-- readme-gen:badges:end -->",
+		"<!-- readme-gen:structure:start -->\n```\n└── src/\n```",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("README.md should contain %q, got:\n%s", want, content)
+		}
+	}
+
+	exitCalled = false
+	if err := runCheck(nil, nil); err != nil {
+		t.Errorf("runCheck() after update error = %v", err)
+	}
+	if exitCalled {
+		t.Error("exitFunc should not be called")
+	}
+
+	// Only the named sections are updated
+	createTestFile(t, "mise.toml", "[tasks.test]\ndescription = \"Run tests\"\nrun = \"go test ./...\"\n")
+	createTestFile(t, "README.md", content+"\n## Development\n\n<!-- readme-gen:tasks:start -->\n<!-- readme-gen:tasks:end -->\n")
+	createTestFile(t, "LICENSE", "Apache License\nVersion 2.0, January 2004")
+	if err := runUpdate(nil, []string{"tasks"}); err != nil {
+		t.Fatalf("runUpdate(tasks) error = %v", err)
+	}
+	updated := readTestFile(t, "README.md")
+	if !strings.Contains(updated, "<!-- readme-gen:tasks:start -->\n| Command | Description |\n|---------|-------------|\n| `mise run test` | Run tests |\n<!-- readme-gen:tasks:end -->") {
+		t.Errorf("README.md should contain the tasks table, got:\n%s", updated)
+	}
+	if !strings.Contains(updated, "License: MIT") {
+		t.Errorf("badges should not be updated, got:\n%s", updated)
+	}
+}
+
+func TestRunCheck_EnvSection(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	exitCalled := false
+	origExitFunc := exitFunc
+	exitFunc = func(code int) { exitCalled = true }
+	defer func() { exitFunc = origExitFunc }()
+
+	createTestFile(t, ".env.example", "# Port to listen on\nPORT=8080\n")
+	createTestFile(t, "main.go", "package main\n\nimport \"os\"\n\nvar _ = os.Getenv(\"REDIS_URL\")\n")
+	createTestFile(t, ".readme-gen.yaml", "env:\n  scan_go: true\n")
+	table := "| Variable | Default | Description |\n|----------|---------|-------------|\n| `PORT` | `8080` | Port to listen on |\n"
+	createTestFile(t, "README.md", "# Project\n\n<!-- readme-gen:env:start -->\n"+table+"<!-- readme-gen:env:end -->\n\n"+
+		"<!-- readme-gen:structure:start -->\n```\n```\n<!-- readme-gen:structure:end -->\n")
+
+	// REDIS_URL is read by the code but missing from the README
+	if err := runCheck(nil, nil); err != ErrOutOfSync {
+		t.Fatalf("runCheck() should return ErrOutOfSync, got: %v", err)
+	}
+	if !exitCalled {
+		t.Error("exitFunc should be called")
+	}
+
+	if err := runUpdate(nil, []string{"env"}); err != nil {
+		t.Fatalf("runUpdate() error = %v", err)
+	}
+	if content := readTestFile(t, "README.md"); !strings.Contains(content, table+"| `REDIS_URL` |  |  |\n<!-- readme-gen:env:end -->") {
+		t.Errorf("README.md should document REDIS_URL, got:\n%s", content)
+	}
+	if err := runCheck(nil, nil); err != nil {
+		t.Errorf("runCheck() after update error = %v", err)
+	}
+}
+
+func TestRunUpdate_RestoreAllReadmes(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	createTestFile(t, "mise.toml", "[tasks.test]\ndescription = \"Run tests\"\nrun = \"go test ./...\"\n")
+	markers := "<!-- readme-gen:tasks:start -->\n<!-- readme-gen:tasks:end -->\n"
+	readme, readmeJa := "# Project\n\n"+markers, "# プロジェクト\n\n"+markers
+	createTestFile(t, "README.md", readme)
+	createTestFile(t, "README.ja.md", readmeJa)
+
+	if err := runUpdate(nil, nil); err != nil {
+		t.Fatalf("runUpdate() error = %v", err)
+	}
+	if readTestFile(t, "README.md") == readme || readTestFile(t, "README.ja.md") == readmeJa {
+		t.Fatal("update should rewrite both READMEs")
+	}
+
+	names, err := backup.List(".")
+	if err != nil || len(names) != 1 {
+		t.Fatalf("backup.List() = %v, %v; want one backup for the update", names, err)
+	}
+
+	if err := runRestore(nil, nil); err != nil {
+		t.Fatalf("runRestore() error = %v", err)
+	}
+	if content := readTestFile(t, "README.md"); content != readme {
+		t.Errorf("README.md should be restored, got:\n%s", content)
+	}
+	if content := readTestFile(t, "README.ja.md"); content != readmeJa {
+		t.Errorf("README.ja.md should be restored, got:\n%s", content)
+	}
+}
+
+func TestRunCheck_SectionsFollowReadmeLanguage(t *testing.T) {
+	_, cleanup := setupTestDir(t)
+	defer cleanup()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	defer i18n.SetLanguage(i18n.Current())
+
+	origExitFunc := exitFunc
+	exitFunc = func(code int) {}
+	defer func() { exitFunc = origExitFunc }()
+
+	createTestFile(t, "mise.toml", "[tasks.test]\ndescription = \"Run tests\"\nrun = \"go test ./...\"\n")
+	markers := "<!-- readme-gen:tasks:start -->\n<!-- readme-gen:tasks:end -->\n"
+	createTestFile(t, "README.md", "# Project\n\n"+markers)
+	createTestFile(t, "README.ja.md", "# プロジェクト\n\n"+markers)
+
+	// The terminal language must not change what update writes or check expects
+	t.Setenv("LANGUAGE", "")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LANG", "ja_JP.UTF-8")
+	i18n.SetLanguage(i18n.DetectLanguage())
+	if err := runCheck(nil, nil); err != ErrOutOfSync {
+		t.Fatalf("runCheck() before update should return ErrOutOfSync, got: %v", err)
+	}
+	if err := runUpdate(nil, nil); err != nil {
+		t.Fatalf("runUpdate() error = %v", err)
+	}
+	if content := readTestFile(t, "README.md"); !strings.Contains(content, "| Command | Description |\n") {
+		t.Errorf("README.md should have English headers, got:\n%s", content)
+	}
+	if content := readTestFile(t, "README.ja.md"); !strings.Contains(content, "| コマンド | 説明 |\n") {
+		t.Errorf("README.ja.md should have Japanese headers, got:\n%s", content)
+	}
+
+	for _, lang := range []string{"C.UTF-8", "ja_JP.UTF-8"} {
+		t.Setenv("LANG", lang)
+		i18n.SetLanguage(i18n.DetectLanguage())
+		if err := runCheck(nil, nil); err != nil {
+			t.Errorf("runCheck() with LANG=%s error = %v", lang, err)
+		}
+	}
+
+	// A stale translation is reported too
+	createTestFile(t, "README.ja.md", "# プロジェクト\n\n"+markers)
+	if err := runCheck(nil, nil); err != ErrOutOfSync {
+		t.Errorf("runCheck() with stale README.ja.md should return ErrOutOfSync, got: %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hulk510/readme-gen/internal/config"
+	"github.com/hulk510/readme-gen/internal/i18n"
+	"github.com/hulk510/readme-gen/internal/marker"
+	"github.com/hulk510/readme-gen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importFile      string
+	importLocale    string
+	importOverwrite bool
+)
+
+var descriptionsCmd = &cobra.Command{
+	Use:   "descriptions",
+	Short: "Manage directory descriptions in .readme-gen.yaml",
+	Long: `Manage the descriptions section of .readme-gen.yaml, which is the source
+of truth for the comments rendered next to directories by structure --update.`,
+}
+
+var descriptionsImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import structure comments from README.md into .readme-gen.yaml",
+	Long:  `Copy the inline comments of the README structure section into the descriptions section of .readme-gen.yaml.`,
+	RunE:  runDescriptionsImport,
+}
+
+func init() {
+	descriptionsImportCmd.Flags().StringVarP(&importFile, "file", "f", "README.md", "README file to import from")
+	descriptionsImportCmd.Flags().StringVar(&importLocale, "locale", "", "Store comments as translations for this language (e.g. ja)")
+	descriptionsImportCmd.Flags().BoolVar(&importOverwrite, "overwrite", false, "Replace descriptions that already exist")
+
+	descriptionsCmd.AddCommand(descriptionsImportCmd)
+}
+
+func runDescriptionsImport(cmd *cobra.Command, args []string) error {
+	msg := i18n.Get()
+
+	content, err := os.ReadFile(importFile)
+	if err != nil {
+		return fmt.Errorf("%s. %s", msg.ReadmeNotFound, msg.RunInitHint)
+	}
+
+	cfg, err := config.Load(".")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	target := structureTarget(cfg)
+
+	structure, found := target.Extract(string(content))
+	if !found {
+		warnNoStructure(msg, target)
+		return nil
+	}
+
+	if cfg.Descriptions == nil {
+		cfg.Descriptions = make(map[string]config.Description)
+	}
+
+	imported := 0
+	for _, e := range marker.ParseTree(structure) {
+		if e.Comment == "" {
+			continue
+		}
+		desc := cfg.Descriptions[e.Path]
+		if desc == nil {
+			desc = make(config.Description)
+		}
+		if _, exists := desc[importLocale]; exists && !importOverwrite {
+			continue
+		}
+		desc[importLocale] = e.Comment
+		cfg.Descriptions[e.Path] = desc
+		imported++
+	}
+
+	if imported == 0 {
+		fmt.Println(ui.Info(msg.NoCommentsFound))
+		return nil
+	}
+
+	if err := backupFile(config.ConfigFileName); err != nil {
+		return err
+	}
+	if err := config.SaveDescriptions(".", cfg.Descriptions); err != nil {
+		return fmt.Errorf("failed to write %s: %w", config.ConfigFileName, err)
+	}
+
+	fmt.Println(ui.Check(fmt.Sprintf(msg.ImportedDescriptions, imported)))
+	return nil
+}
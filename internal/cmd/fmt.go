@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/hulk510/readme-gen/internal/config"
+	"github.com/hulk510/readme-gen/internal/i18n"
+	"github.com/hulk510/readme-gen/internal/marker"
+	"github.com/hulk510/readme-gen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// ErrNotFormatted is returned by fmt --check when comments need formatting (for testing)
+var ErrNotFormatted = errors.New("structure comments not formatted")
+
+var (
+	fmtWidth int
+	fmtCheck bool
+)
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt",
+	Short: "Align and normalize structure comments",
+	Long: `Align all comments in the README.md structure section to a common column,
+normalize the separator to "# " and wrap long comments onto continuation lines.`,
+	RunE: runFmt,
+}
+
+func init() {
+	fmtCmd.Flags().IntVarP(&fmtWidth, "width", "w", 0, "Wrap comments to this line width (default: structure.comment_width)")
+	fmtCmd.Flags().BoolVar(&fmtCheck, "check", false, "Exit with code 1 if comments are not formatted instead of writing")
+}
+
+func runFmt(cmd *cobra.Command, args []string) error {
+	msg := i18n.Get()
+
+	content, err := os.ReadFile("README.md")
+	if err != nil {
+		return fmt.Errorf("%s. %s", msg.ReadmeNotFound, msg.RunInitHint)
+	}
+
+	cfg, err := config.Load(".")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	target := structureTarget(cfg)
+
+	structure, found := target.Extract(string(content))
+	if !found {
+		warnNoStructure(msg, target)
+		return nil
+	}
+
+	width := fmtWidth
+	if width == 0 {
+		width = cfg.Structure.CommentWidth
+	}
+
+	formatted := marker.Format(structure, width)
+	if formatted == structure {
+		fmt.Println(ui.Check(msg.AlreadyFormatted))
+		return nil
+	}
+
+	if fmtCheck {
+		fmt.Println(ui.Warn(msg.NotFormatted))
+		fmt.Println(ui.Info(msg.RunFmtHint))
+		exitFunc(1)
+		return ErrNotFormatted
+	}
+
+	newContent, err := target.Update(string(content), formatted)
+	if err != nil {
+		return fmt.Errorf("failed to update structure: %w", err)
+	}
+
+	if err := writeFile("README.md", []byte(newContent)); err != nil {
+		return err
+	}
+
+	fmt.Println(ui.Check(msg.FormattedReadme))
+	return nil
+}
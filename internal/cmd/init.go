@@ -2,17 +2,21 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/huh/spinner"
 	"github.com/hulk510/readme-gen/internal/config"
 	"github.com/hulk510/readme-gen/internal/i18n"
+	"github.com/hulk510/readme-gen/internal/marker"
 	"github.com/hulk510/readme-gen/internal/scanner"
 	"github.com/hulk510/readme-gen/internal/template"
 	"github.com/hulk510/readme-gen/internal/ui"
@@ -26,22 +30,39 @@ var (
 	withAI         bool
 	noSkills       bool
 	noAI           bool
+	adoptFlag      bool
+	forceFlag      bool
 )
 
+// ErrReadmeExists is returned when init would overwrite README.md without --force
+var ErrReadmeExists = errors.New("README.md already exists")
+
+// ErrInterrupted is returned when AI generation is cancelled by a signal
+var ErrInterrupted = errors.New("interrupted")
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize README.md from template",
-	Long:  `Create a new README.md file from a template with directory structure.`,
-	RunE:  runInit,
+	Long: `Create a new README.md file from a template with directory structure.
+
+With --adopt, an existing README.md is kept: only the template sections it lacks
+are added, and structure markers are inserted.
+
+In non-interactive mode (--yes) an existing README.md is only overwritten with
+--force. The previous content is saved under .readme-gen/backups and can be
+brought back with 'readme-gen restore'.`,
+	RunE: runInit,
 }
 
 func init() {
-	initCmd.Flags().StringVarP(&templateFlag, "template", "t", "", "Template to use (oss, general)")
+	initCmd.Flags().StringVarP(&templateFlag, "template", "t", "", "Template to use (oss, general, cli, library, service, monorepo, or a project/user template)")
 	initCmd.Flags().BoolVarP(&nonInteractive, "yes", "y", false, "Non-interactive mode with defaults")
 	initCmd.Flags().BoolVar(&withSkills, "with-skills", false, "Add Claude Code skills")
 	initCmd.Flags().BoolVar(&withAI, "with-ai", false, "Generate descriptions with AI")
 	initCmd.Flags().BoolVar(&noSkills, "no-skills", false, "Skip adding Claude Code skills")
 	initCmd.Flags().BoolVar(&noAI, "no-ai", false, "Skip AI generation")
+	initCmd.Flags().BoolVar(&adoptFlag, "adopt", false, "Keep existing README.md and add only missing template sections")
+	initCmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Overwrite an existing README.md in non-interactive mode")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
@@ -49,9 +70,16 @@ func runInit(cmd *cobra.Command, args []string) error {
 	fmt.Println(ui.Title())
 
 	// Check if README already exists
-	if _, err := os.Stat("README.md"); err == nil {
+	_, statErr := os.Stat("README.md")
+	adopting := adoptFlag && statErr == nil
+	if statErr == nil && !adopting {
 		fmt.Println(ui.Warn("README.md already exists"))
 		var overwrite bool
+		if nonInteractive && !forceFlag {
+			fmt.Println(ui.Err(msg.UseForceToOverwrite))
+			exitFunc(1)
+			return ErrReadmeExists
+		}
 		if !nonInteractive {
 			err := huh.NewConfirm().
 				Title(msg.OverwriteConfirm).
@@ -75,6 +103,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	// Detect project info
 	info := scanner.DetectProjectInfo(".")
+	loader := template.NewLoader(".")
 	projectName = info.Name
 
 	if nonInteractive {
@@ -126,10 +155,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 			huh.NewGroup(
 				huh.NewSelect[string]().
 					Title(msg.SelectTemplate).
-					Options(
-						huh.NewOption(msg.TemplateOSS, "oss"),
-						huh.NewOption(msg.TemplateGeneral, "general"),
-					).
+					Options(templateOptions(msg, loader)...).
 					Value(&selectedTemplate),
 			),
 		)
@@ -172,31 +198,36 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Generate structure
-	structure, err := scanner.ScanAuto(".")
+	cfg, err := config.Load(".")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	structure, err := generateStructure(".", cfg, i18n.Current())
 	if err != nil {
 		return fmt.Errorf("failed to scan directory: %w", err)
 	}
 
 	// Generate README
-	data := template.Data{
-		ProjectName: projectName,
-		Description: info.Description,
-		Structure:   structure,
-		Language:    info.Language,
-		ModulePath:  info.ModulePath,
-		Lang:        i18n.Current(),
-	}
+	data := templateData(info, structure, i18n.Current())
+	data.ProjectName = projectName
 
-	content, err := template.Render(selectedTemplate, data)
+	content, err := loader.Render(selectedTemplate, data)
 	if err != nil {
 		return fmt.Errorf("failed to render template: %w", err)
 	}
 
-	// Write README.md
-	if err := os.WriteFile("README.md", []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to write README.md: %w", err)
+	if adopting {
+		written, err := adoptReadme(msg, structureTarget(cfg), content)
+		if err != nil || !written {
+			return err
+		}
+	} else {
+		// Write README.md
+		if err := writeFile("README.md", []byte(content)); err != nil {
+			return err
+		}
+		fmt.Println(ui.Success(msg.CreatedReadme))
 	}
-	fmt.Println(ui.Success(msg.CreatedReadme))
 
 	// Add Claude Code skills if requested
 	if contains(selectedOptions, "skills") {
@@ -207,8 +238,8 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 		skillsContent := template.GetClaudeSkills()
 		skillsPath := filepath.Join(skillsDir, "readme.md")
-		if err := os.WriteFile(skillsPath, []byte(skillsContent), 0644); err != nil {
-			return fmt.Errorf("failed to write skills file: %w", err)
+		if err := writeFile(skillsPath, []byte(skillsContent)); err != nil {
+			return err
 		}
 		fmt.Println(ui.Success(msg.CreatedSkills))
 	}
@@ -216,19 +247,133 @@ func runInit(cmd *cobra.Command, args []string) error {
 	// Generate descriptions with AI if requested
 	if contains(selectedOptions, "ai") {
 		if err := generateWithAI(msg); err != nil {
+			if errors.Is(err, ErrInterrupted) {
+				return err
+			}
 			fmt.Println(ui.Warn(err.Error()))
 		}
 	}
 
 	// Print helpful info
 	fmt.Println()
-	fmt.Println(ui.Box(msg.MarkersInfo + ":\n\n<!-- readme-gen:structure:start -->\n<!-- readme-gen:structure:end -->"))
+	if cfg.Structure.Heading != "" {
+		fmt.Println(ui.Box(fmt.Sprintf(msg.HeadingInfo, cfg.Structure.Heading)))
+	} else {
+		fmt.Println(ui.Box(msg.MarkersInfo + ":\n\n<!-- readme-gen:structure:start -->\n<!-- readme-gen:structure:end -->"))
+	}
 	fmt.Println()
 	fmt.Println(ui.Info(msg.RunLaterHint))
 
 	return nil
 }
 
+// templateData returns the template data for a project
+func templateData(info scanner.ProjectInfo, structure string, lang i18n.Language) template.Data {
+	return template.Data{
+		ProjectName:   info.Name,
+		Description:   info.Description,
+		Structure:     structure,
+		Language:      info.Language,
+		ModulePath:    info.ModulePath,
+		Version:       info.Version,
+		Ecosystem:     info.Ecosystem,
+		Languages:     info.Languages,
+		Ecosystems:    info.Ecosystems,
+		License:       info.License,
+		LicenseFile:   info.LicenseFile,
+		RepoURL:       info.RepoURL,
+		DefaultBranch: info.DefaultBranch,
+		LatestTag:     info.LatestTag,
+		GoVersion:     info.GoVersion,
+		NodeVersion:   info.NodeVersion,
+		Tasks:         info.Tasks,
+		Binaries:      info.Binaries,
+		Lang:          lang,
+		Packages:      info.Packages,
+	}
+}
+
+// adoptReadme merges the missing sections of the rendered template into the
+// existing README.md after showing what will be added. It reports whether
+// README.md was written. In heading mode the structure section is added
+// without markers.
+func adoptReadme(msg i18n.Messages, target marker.Target, rendered string) (bool, error) {
+	existing, err := os.ReadFile("README.md")
+	if err != nil {
+		return false, fmt.Errorf("failed to read README.md: %w", err)
+	}
+
+	merged, added, err := target.Adopt(string(existing), rendered)
+	if err != nil {
+		return false, fmt.Errorf("failed to adopt README.md: %w", err)
+	}
+
+	if len(added) == 0 {
+		fmt.Println(ui.Check(msg.AdoptNothing))
+		return false, nil
+	}
+
+	var preview strings.Builder
+	preview.WriteString(msg.AdoptPreview + ":\n")
+	for _, title := range added {
+		preview.WriteString("\n  + ## " + title)
+	}
+	fmt.Println()
+	fmt.Println(ui.Box(preview.String()))
+	fmt.Println()
+
+	if !nonInteractive {
+		var confirmed bool
+		err := huh.NewConfirm().
+			Title(msg.AdoptConfirm).
+			Value(&confirmed).
+			Run()
+		if err != nil {
+			return false, err
+		}
+		if !confirmed {
+			fmt.Println(ui.Info(msg.Cancelled))
+			return false, nil
+		}
+	}
+
+	if err := writeFile("README.md", []byte(merged)); err != nil {
+		return false, err
+	}
+	fmt.Println(ui.Success(fmt.Sprintf(msg.AdoptedReadme, len(added))))
+	return true, nil
+}
+
+// templateOptions returns the wizard choices: project and user templates
+// first, so that a team's own layout is the default, then built-in templates
+func templateOptions(msg i18n.Messages, loader *template.Loader) []huh.Option[string] {
+	var options []huh.Option[string]
+	builtin := make(map[string]bool)
+	for _, info := range loader.List() {
+		if info.Source.Kind == template.SourceBuiltin {
+			builtin[info.Name] = true
+			continue
+		}
+		label := fmt.Sprintf("%s - %s", info.Name, info.Source.Dir)
+		options = append(options, huh.NewOption(label, info.Name))
+	}
+
+	builtinOptions := []huh.Option[string]{
+		huh.NewOption(msg.TemplateOSS, "oss"),
+		huh.NewOption(msg.TemplateGeneral, "general"),
+		huh.NewOption(msg.TemplateCLI, "cli"),
+		huh.NewOption(msg.TemplateLibrary, "library"),
+		huh.NewOption(msg.TemplateService, "service"),
+		huh.NewOption(msg.TemplateMonorepo, "monorepo"),
+	}
+	for _, option := range builtinOptions {
+		if builtin[option.Value] {
+			options = append(options, option)
+		}
+	}
+	return options
+}
+
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
@@ -295,31 +440,69 @@ Rules:
 - Preserve existing markers (<!-- readme-gen:structure:start/end -->)`, additionalContext)
 	}
 
-	// Run claude command with timeout and spinner
-	ctx, cancel := context.WithTimeout(context.Background(), aiTimeout)
+	// Claude edits README.md in place; keep the current content so that a
+	// failed or interrupted run can be rolled back, and back it up so that
+	// a successful run can be undone with restore
+	original, err := os.ReadFile("README.md")
+	if err != nil {
+		return fmt.Errorf("failed to read README.md: %w", err)
+	}
+	if err := backupFile("README.md"); err != nil {
+		return err
+	}
+
+	// Run claude command with timeout and spinner, cancelled on Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, aiTimeout)
 	defer cancel()
 
 	var runErr error
-	action := func() {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
 		claudeCmd := exec.CommandContext(ctx, "claude", "-p", prompt, "--allowedTools", "Read,Edit,Glob,Grep")
 		runErr = claudeCmd.Run()
+	}()
+
+	action := func(ctx context.Context) error {
+		select {
+		case <-done:
+		case <-ctx.Done():
+		}
+		return nil
 	}
 
 	spinnerTitle := msg.GeneratingWithAI
-	if err := spinner.New().Title(spinnerTitle).Action(action).Run(); err != nil {
-		return fmt.Errorf("%s: %w", msg.AIGenerationFailed, err)
+	spinErr := spinner.New().Title(spinnerTitle).Context(ctx).ActionWithErr(action).Run()
+
+	// The command is killed when ctx is cancelled; wait until it has exited
+	// before touching README.md
+	<-done
+
+	var failure error
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		failure = fmt.Errorf("%s: timeout after %v", msg.AIGenerationFailed, aiTimeout)
+	case ctx.Err() != nil:
+		failure = fmt.Errorf("%s: %w", msg.AIGenerationFailed, ErrInterrupted)
+	case runErr != nil:
+		failure = fmt.Errorf("%s: %w", msg.AIGenerationFailed, runErr)
+	case spinErr != nil:
+		failure = fmt.Errorf("%s: %w", msg.AIGenerationFailed, spinErr)
 	}
 
-	if ctx.Err() == context.DeadlineExceeded {
-		return fmt.Errorf("%s: timeout after %v", msg.AIGenerationFailed, aiTimeout)
+	if failure == nil {
+		fmt.Println(ui.Success(msg.AddedDescriptions))
+		return nil
 	}
 
-	if runErr != nil {
-		return fmt.Errorf("%s: %w", msg.AIGenerationFailed, runErr)
+	if err := rollbackFile("README.md", original); err != nil {
+		fmt.Println(ui.Err(fmt.Sprintf(msg.RollbackFailed, "README.md", err)))
+	} else {
+		fmt.Println(ui.Info(fmt.Sprintf(msg.RolledBack, "README.md")))
 	}
-
-	fmt.Println(ui.Success(msg.AddedDescriptions))
-	return nil
+	return failure
 }
 
 // collectProjectContext gathers additional context from project files
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hulk510/readme-gen/internal/backup"
+	"github.com/hulk510/readme-gen/internal/i18n"
+	"github.com/hulk510/readme-gen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var restoreList bool
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Roll back the last change made by readme-gen",
+	Long: `Restore the files saved in the most recent backup under .readme-gen/backups.
+
+readme-gen backs up README.md (and other files it rewrites) before every
+destructive write, one backup per command. Restore puts back every file of
+that backup and removes it, so running restore repeatedly steps further
+back in time.`,
+	RunE: runRestore,
+}
+
+func init() {
+	restoreCmd.Flags().BoolVar(&restoreList, "list", false, "List available backups without restoring")
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	msg := i18n.Get()
+	fmt.Println(ui.Title())
+
+	names, err := backup.List(".")
+	if err != nil {
+		return fmt.Errorf("failed to read backups: %w", err)
+	}
+	if len(names) == 0 {
+		fmt.Println(ui.Info(msg.NoBackups))
+		return nil
+	}
+
+	if restoreList {
+		fmt.Println(ui.Box(msg.AvailableBackups + ":\n\n  " + strings.Join(names, "\n  ")))
+		return nil
+	}
+
+	name, restored, err := backup.Restore(".")
+	if err != nil {
+		return fmt.Errorf("failed to restore backup: %w", err)
+	}
+
+	for _, path := range restored {
+		fmt.Println(ui.Check(fmt.Sprintf(msg.RestoredFile, path)))
+	}
+	fmt.Println(ui.Success(fmt.Sprintf(msg.RestoredBackup, name)))
+	return nil
+}
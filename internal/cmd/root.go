@@ -14,11 +14,14 @@ var rootCmd = &cobra.Command{
 directory structure updates and consistent templates.
 
 Features:
-  - Multiple templates (oss, personal, team)
+  - Multiple templates (oss, general, cli, library, service, monorepo)
   - Marker-based structure auto-sync
   - Claude Code integration support
   - CI-friendly check command`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Each command invocation writes its own backup
+		backupName = ""
+
 		// Set language
 		switch langFlag {
 		case "ja", "jp", "japanese":
@@ -43,4 +46,10 @@ func init() {
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(structureCmd)
 	rootCmd.AddCommand(checkCmd)
+	rootCmd.AddCommand(descriptionsCmd)
+	rootCmd.AddCommand(fmtCmd)
+	rootCmd.AddCommand(restoreCmd)
+	rootCmd.AddCommand(templateCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(updateCmd)
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/hulk510/readme-gen/internal/config"
 	"github.com/hulk510/readme-gen/internal/i18n"
 	"github.com/hulk510/readme-gen/internal/marker"
 	"github.com/hulk510/readme-gen/internal/scanner"
@@ -11,28 +12,51 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var updateFlag bool
+var (
+	updateFlag     bool
+	formatFlag     bool
+	insertFlag     bool
+	insertPosition string
+)
 
 var structureCmd = &cobra.Command{
 	Use:   "structure",
 	Short: "Show or update directory structure",
-	Long:  `Display current directory structure or update the structure section in README.md.`,
-	RunE:  runStructure,
+	Long: `Display current directory structure or update the structure section in README.md.
+
+With --insert, structure markers are added to a README.md that has none: below an
+existing "## Structure" (or similar) heading, or as a new section at --position.
+
+With --update --fmt, comments are aligned, normalized and wrapped as by 'readme-gen fmt'.`,
+	RunE: runStructure,
 }
 
 func init() {
 	structureCmd.Flags().BoolVarP(&updateFlag, "update", "u", false, "Update README.md structure section")
+	structureCmd.Flags().BoolVar(&formatFlag, "fmt", false, "Align and wrap structure comments when updating, as readme-gen fmt does")
+	structureCmd.Flags().BoolVar(&insertFlag, "insert", false, "Insert structure markers into a README.md without them")
+	structureCmd.Flags().StringVar(&insertPosition, "position", marker.PositionEnd, "Where to add a new section: end, top, before:<heading> or after:<heading>")
 }
 
 func runStructure(cmd *cobra.Command, args []string) error {
 	msg := i18n.Get()
 
+	cfg, err := config.Load(".")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	target := structureTarget(cfg)
+
 	// Scan directory
-	structure, err := scanner.ScanAuto(".")
+	structure, err := generateStructure(".", cfg, i18n.Current())
 	if err != nil {
 		return fmt.Errorf("failed to scan directory: %w", err)
 	}
 
+	if insertFlag {
+		return insertStructure(msg, target, structure)
+	}
+
 	if !updateFlag {
 		// Just print structure
 		fmt.Println(structure)
@@ -50,26 +74,82 @@ func runStructure(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get current structure from README
-	oldStructure, found := marker.Extract(string(content))
+	oldStructure, found := target.Extract(string(content))
+
+	if formatFlag {
+		structure = marker.Format(structure, cfg.Structure.CommentWidth)
+	}
 
 	// Update markers
-	newContent, err := marker.Update(string(content), structure)
+	newContent, err := target.Update(string(content), structure)
 	if err != nil {
 		return fmt.Errorf("failed to update structure: %w", err)
 	}
 
 	// Show diff if there were changes (strip comments for comparison)
-	if found && marker.StripComments(oldStructure) != structure {
+	if found && marker.StripComments(oldStructure) != marker.StripComments(structure) {
 		fmt.Println()
 		fmt.Println(ui.Box(fmt.Sprintf("%s:\n\nOld:\n%s\n\nNew:\n%s", msg.ChangesDetected, oldStructure, structure)))
 		fmt.Println()
 	}
 
 	// Write updated README
-	if err := os.WriteFile("README.md", []byte(newContent), 0644); err != nil {
-		return fmt.Errorf("failed to write README.md: %w", err)
+	if err := writeFile("README.md", []byte(newContent)); err != nil {
+		return err
 	}
 
 	fmt.Println(ui.Check(msg.UpdatedReadme))
 	return nil
 }
+
+// insertStructure adds markers and the structure to README.md
+// (or a plain code block under the configured heading in heading mode)
+func insertStructure(msg i18n.Messages, target marker.Target, structure string) error {
+	fmt.Println(ui.Title())
+
+	content, err := os.ReadFile("README.md")
+	if err != nil {
+		return fmt.Errorf("%s. %s", msg.ReadmeNotFound, msg.RunInitHint)
+	}
+
+	if _, found := target.Extract(string(content)); found {
+		fmt.Println(ui.Info(msg.MarkersAlreadyExist))
+		return nil
+	}
+
+	newContent, err := target.Insert(string(content), structure, marker.InsertOptions{
+		Position: insertPosition,
+		Heading:  "## " + msg.StructureHeading,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert structure: %w", err)
+	}
+
+	if err := writeFile("README.md", []byte(newContent)); err != nil {
+		return err
+	}
+
+	fmt.Println(ui.Check(msg.InsertedMarkers))
+	return nil
+}
+
+// structureTarget returns the README block managed as the structure section
+func structureTarget(cfg *config.Config) marker.Target {
+	return marker.Target{Heading: cfg.Structure.Heading}
+}
+
+// warnNoStructure explains why the structure section could not be found
+func warnNoStructure(msg i18n.Messages, target marker.Target) {
+	if target.Heading != "" {
+		fmt.Println(ui.Warn(fmt.Sprintf(msg.NoStructureSection, target.Heading)))
+		return
+	}
+	fmt.Println(ui.Warn(msg.NoMarkersFound))
+	fmt.Println(ui.Info(msg.AddMarkersHint))
+}
+
+// generateStructure scans root with its configuration and annotates
+// directories with comments from description sources and the config
+func generateStructure(root string, cfg *config.Config, lang i18n.Language) (string, error) {
+	return scanner.Structure(root, "", cfg, string(lang), 0)
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hulk510/readme-gen/internal/config"
+	"github.com/hulk510/readme-gen/internal/i18n"
+	"github.com/hulk510/readme-gen/internal/scanner"
+	"github.com/hulk510/readme-gen/internal/template"
+	"github.com/hulk510/readme-gen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Regenerate README.md from README.md.tmpl",
+	Long: `Render README.md.tmpl (and localized README.<lang>.md.tmpl files) in the
+project root into README.md with the current structure and project info.
+
+The template has the same data, functions and partials as init templates and
+may extend one of them. 'readme-gen check' fails when README.md differs from
+what sync would generate.`,
+	RunE: runSync,
+}
+
+// renderedReadme is the content generated from a README template
+type renderedReadme struct {
+	template.ReadmeTemplate
+	content string
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	msg := i18n.Get()
+	fmt.Println(ui.Title())
+
+	readmes, err := renderReadmes(".")
+	if err != nil {
+		return err
+	}
+	if len(readmes) == 0 {
+		fmt.Println(ui.Info(msg.NoReadmeTemplates))
+		return nil
+	}
+
+	for _, r := range readmes {
+		if current, err := os.ReadFile(r.Output); err == nil && string(current) == r.content {
+			fmt.Println(ui.Check(fmt.Sprintf(msg.ReadmeMatchesTemplate, r.Output, r.Path)))
+			continue
+		}
+		if err := writeFile(r.Output, []byte(r.content)); err != nil {
+			return err
+		}
+		fmt.Println(ui.Success(fmt.Sprintf(msg.RenderedReadme, r.Output, r.Path)))
+	}
+	return nil
+}
+
+// renderReadmes renders the README templates in root
+func renderReadmes(root string) ([]renderedReadme, error) {
+	templates, err := template.FindReadmeTemplates(root)
+	if err != nil || len(templates) == 0 {
+		return nil, err
+	}
+
+	cfg, err := config.Load(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	info := scanner.DetectProjectInfo(root)
+	loader := template.NewLoader(root)
+
+	var readmes []renderedReadme
+	for _, t := range templates {
+		source, err := os.ReadFile(t.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", t.Path, err)
+		}
+		structure, err := generateStructure(root, cfg, t.Lang)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan directory: %w", err)
+		}
+
+		content, err := loader.RenderContent(t.Path, string(source), templateData(info, structure, t.Lang))
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", t.Path, err)
+		}
+		readmes = append(readmes, renderedReadme{ReadmeTemplate: t, content: content})
+	}
+	return readmes, nil
+}
+
+// checkReadmes reports READMEs that differ from their templates and
+// returns whether all of them are up to date
+func checkReadmes(msg i18n.Messages, readmes []renderedReadme) bool {
+	ok := true
+	for _, r := range readmes {
+		current, err := os.ReadFile(r.Output)
+		if err != nil {
+			fmt.Println(ui.Warn(fmt.Sprintf(msg.ReadmeNotGenerated, r.Output, r.Path)))
+			ok = false
+			continue
+		}
+		if line := firstDifference(string(current), r.content); line > 0 {
+			fmt.Println(ui.Warn(fmt.Sprintf(msg.ReadmeDiffers, r.Output, r.Path, line)))
+			ok = false
+			continue
+		}
+		fmt.Println(ui.Check(fmt.Sprintf(msg.ReadmeMatchesTemplate, r.Output, r.Path)))
+	}
+
+	if !ok {
+		fmt.Println()
+		fmt.Println(ui.Info(msg.RunSyncHint))
+	}
+	return ok
+}
+
+// firstDifference returns the 1-based line where a and b first differ,
+// or 0 if they are equal
+func firstDifference(a, b string) int {
+	if a == b {
+		return 0
+	}
+	linesA := strings.Split(a, "\n")
+	linesB := strings.Split(b, "\n")
+	for i := range linesA {
+		if i >= len(linesB) || linesA[i] != linesB[i] {
+			return i + 1
+		}
+	}
+	return len(linesA) + 1
+}
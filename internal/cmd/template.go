@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hulk510/readme-gen/internal/i18n"
+	"github.com/hulk510/readme-gen/internal/template"
+	"github.com/hulk510/readme-gen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// ErrTemplateLint is returned when template lint finds problems
+var ErrTemplateLint = errors.New("template lint failed")
+
+var ejectForce bool
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "List, inspect and customize README templates",
+	Long: `Manage README templates.
+
+Templates are looked up in .readme-gen/templates in the project, then in
+$XDG_CONFIG_HOME/readme-gen/templates, then among the built-in templates.`,
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available templates",
+	Args:  cobra.NoArgs,
+	RunE:  runTemplateList,
+}
+
+var templateShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Print the source of a template",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTemplateShow,
+}
+
+var templateEjectCmd = &cobra.Command{
+	Use:   "eject <name>",
+	Short: "Copy a built-in template into .readme-gen/templates for customization",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTemplateEject,
+}
+
+var templateLintCmd = &cobra.Command{
+	Use:   "lint [name|file...]",
+	Short: "Check templates for syntax errors and unknown fields",
+	Long: `Parse templates and verify that they reference .Structure and only
+fields that readme-gen provides.
+
+Without arguments, all project and user templates are checked.`,
+	RunE: runTemplateLint,
+}
+
+func init() {
+	templateEjectCmd.Flags().BoolVarP(&ejectForce, "force", "f", false, "Replace an existing project template")
+
+	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateShowCmd)
+	templateCmd.AddCommand(templateEjectCmd)
+	templateCmd.AddCommand(templateLintCmd)
+}
+
+func runTemplateList(cmd *cobra.Command, args []string) error {
+	loader := template.NewLoader(".")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, info := range loader.List() {
+		content, _, err := loader.Load(info.Name, i18n.Current())
+		if err != nil {
+			return err
+		}
+
+		var langs []string
+		for _, lang := range info.Langs {
+			langs = append(langs, string(lang))
+		}
+
+		source := info.Source.Kind
+		if info.Source.Dir != "" {
+			source = info.Source.Dir
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", info.Name, strings.Join(langs, ","), source, template.Describe(content))
+	}
+	return w.Flush()
+}
+
+func runTemplateShow(cmd *cobra.Command, args []string) error {
+	content, _, err := template.NewLoader(".").Load(args[0], i18n.Current())
+	if err != nil {
+		return err
+	}
+	fmt.Print(content)
+	return nil
+}
+
+func runTemplateEject(cmd *cobra.Command, args []string) error {
+	msg := i18n.Get()
+
+	written, err := template.Eject(".", args[0], ejectForce)
+	if errors.Is(err, fs.ErrExist) {
+		fmt.Println(ui.Warn(msg.TemplateExistsHint))
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to eject template: %w", err)
+	}
+
+	for _, path := range written {
+		fmt.Println(ui.Check(fmt.Sprintf(msg.EjectedTemplate, path)))
+	}
+	return nil
+}
+
+// lintTarget is a template file to lint
+type lintTarget struct {
+	name    string
+	label   string
+	content string
+}
+
+func runTemplateLint(cmd *cobra.Command, args []string) error {
+	msg := i18n.Get()
+
+	loader := template.NewLoader(".")
+	targets, err := lintTargets(loader, args)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		fmt.Println(ui.Info(msg.NoTemplates))
+		return nil
+	}
+
+	problems := 0
+	for _, t := range targets {
+		issues := loader.Lint(t.name, t.content)
+		if len(issues) == 0 {
+			fmt.Println(ui.Check(fmt.Sprintf(msg.TemplateLintOK, t.label)))
+			continue
+		}
+
+		problems += len(issues)
+		fmt.Println(ui.Warn(t.label))
+		for _, issue := range issues {
+			switch issue.Kind {
+			case template.LintParse:
+				fmt.Printf("  %s\n", issue.Detail)
+			case template.LintMissingStructure:
+				fmt.Printf("  %s\n", msg.TemplateMissingStructure)
+			case template.LintUnknownField:
+				fmt.Printf("  %s  %s\n", issue.Location, fmt.Sprintf(msg.TemplateUnknownField, issue.Detail))
+			}
+		}
+	}
+
+	if problems > 0 {
+		fmt.Println()
+		fmt.Println(ui.Err(fmt.Sprintf(msg.TemplateLintFailed, problems)))
+		exitFunc(1)
+		return ErrTemplateLint
+	}
+	return nil
+}
+
+// lintTargets resolves lint arguments to template files. Arguments naming
+// an existing file are read directly; other arguments are template names,
+// checked in every language variant. Without arguments all project and
+// user templates are returned.
+func lintTargets(loader *template.Loader, args []string) ([]lintTarget, error) {
+	var targets []lintTarget
+
+	if len(args) == 0 {
+		for _, info := range loader.List() {
+			if info.Source.Kind == template.SourceBuiltin {
+				continue
+			}
+			args = append(args, info.Name)
+		}
+	}
+
+	for _, arg := range args {
+		if info, err := os.Stat(arg); err == nil && !info.IsDir() {
+			content, err := os.ReadFile(arg)
+			if err != nil {
+				return nil, err
+			}
+			targets = append(targets, lintTarget{name: template.NameOf(arg), label: filepath.Base(arg), content: string(content)})
+			continue
+		}
+
+		found := false
+		for _, info := range loader.List() {
+			if info.Name != arg {
+				continue
+			}
+			found = true
+			for _, lang := range info.Langs {
+				content, _, err := loader.Load(info.Name, lang)
+				if err != nil {
+					return nil, err
+				}
+				targets = append(targets, lintTarget{name: info.Name, label: fmt.Sprintf("%s (%s)", info.Name, lang), content: content})
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("template '%s' not found", arg)
+		}
+	}
+	return targets, nil
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hulk510/readme-gen/internal/config"
+	"github.com/hulk510/readme-gen/internal/i18n"
+	"github.com/hulk510/readme-gen/internal/marker"
+	"github.com/hulk510/readme-gen/internal/section"
+	"github.com/hulk510/readme-gen/internal/template"
+	"github.com/hulk510/readme-gen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var updateCmd = &cobra.Command{
+	Use:   "update [section...]",
+	Short: "Regenerate the generated sections of README.md",
+	Long: `Regenerate the sections of README.md and its translations (README.<lang>.md)
+between <!-- readme-gen:<name>:start --> and <!-- readme-gen:<name>:end -->
+markers from the project, or only the named sections. Each file is written in
+the language of its name.
+
+Available sections: ` + strings.Join(section.Names(), ", ") + `. The structure section is updated
+with 'readme-gen structure --update'. 'readme-gen check' fails when a
+section is out of date.`,
+	RunE: runUpdate,
+}
+
+func runUpdate(cmd *cobra.Command, args []string) error {
+	msg := i18n.Get()
+	fmt.Println(ui.Title())
+
+	if _, err := os.Stat("README.md"); err != nil {
+		return fmt.Errorf("%s. %s", msg.ReadmeNotFound, msg.RunInitHint)
+	}
+
+	cfg, err := config.Load(".")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	readmes, err := managedReadmes(msg, args)
+	if err != nil {
+		return err
+	}
+	if len(readmes) == 0 {
+		fmt.Println(ui.Info(msg.NoManagedSections))
+		fmt.Println(ui.Info(msg.AddSectionHint))
+		return nil
+	}
+
+	for _, readme := range readmes {
+		updated := readme.content
+		for _, name := range readme.sections {
+			body, err := section.Render(name, ".", string(readme.lang), cfg)
+			if err != nil {
+				return fmt.Errorf("failed to render %s section: %w", name, err)
+			}
+			if current, _ := marker.ExtractManaged(updated, name); current == strings.Trim(body, "\n") {
+				fmt.Println(ui.Check(fmt.Sprintf(msg.SectionUpToDate, readme.path, name)))
+				continue
+			}
+			updated, err = marker.UpdateManaged(updated, name, body)
+			if err != nil {
+				return fmt.Errorf("failed to update %s section: %w", name, err)
+			}
+			fmt.Println(ui.Success(fmt.Sprintf(msg.SectionUpdated, readme.path, name)))
+		}
+
+		if updated == readme.content {
+			continue
+		}
+		if err := writeFile(readme.path, []byte(updated)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// managedReadme is a README with generated sections
+type managedReadme struct {
+	path     string
+	content  string
+	lang     i18n.Language
+	sections []string
+}
+
+// managedReadmes returns README.md and its translations (README.<lang>.md)
+// that have generated sections, limited to the sections in only if given.
+// Sections are rendered in the language of the file, not of the terminal.
+func managedReadmes(msg i18n.Messages, only []string) ([]managedReadme, error) {
+	files, err := filepath.Glob("README*.md")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var readmes []managedReadme
+	for _, file := range files {
+		lang, ok := template.ReadmeLanguage(file)
+		if !ok {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		sections := generatedSections(msg, string(content), only)
+		if len(sections) == 0 {
+			continue
+		}
+		readmes = append(readmes, managedReadme{path: file, content: string(content), lang: lang, sections: sections})
+	}
+	return readmes, nil
+}
+
+// generatedSections returns the managed sections in content that readme-gen
+// generates, limited to only if given. The structure section is left to the
+// structure command; unknown sections are reported and skipped.
+func generatedSections(msg i18n.Messages, content string, only []string) []string {
+	var names []string
+	for _, name := range marker.ManagedNames(content) {
+		if name == marker.StructureSection || (len(only) > 0 && !slices.Contains(only, name)) {
+			continue
+		}
+		if !section.Known(name) {
+			fmt.Println(ui.Warn(fmt.Sprintf(msg.UnknownSection, name)))
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// checkSections reports whether every generated section of the READMEs
+// matches what update would write
+func checkSections(msg i18n.Messages, cfg *config.Config) (bool, error) {
+	readmes, err := managedReadmes(msg, nil)
+	if err != nil {
+		return false, err
+	}
+
+	inSync := true
+	for _, readme := range readmes {
+		for _, name := range readme.sections {
+			body, err := section.Render(name, ".", string(readme.lang), cfg)
+			if err != nil {
+				return false, fmt.Errorf("failed to render %s section: %w", name, err)
+			}
+			if current, _ := marker.ExtractManaged(readme.content, name); current != strings.Trim(body, "\n") {
+				fmt.Println(ui.Warn(fmt.Sprintf(msg.SectionOutOfSync, readme.path, name)))
+				inSync = false
+				continue
+			}
+			fmt.Println(ui.Check(fmt.Sprintf(msg.SectionUpToDate, readme.path, name)))
+		}
+	}
+	if !inSync {
+		fmt.Println(ui.Info(msg.RunUpdateSectionHint))
+	}
+	return inSync, nil
+}
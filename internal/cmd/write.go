@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hulk510/readme-gen/internal/backup"
+	"github.com/hulk510/readme-gen/internal/fileutil"
+	"github.com/hulk510/readme-gen/internal/i18n"
+	"github.com/hulk510/readme-gen/internal/ui"
+)
+
+// writeFile writes content to path, first saving the previous content
+// under .readme-gen/backups so that `readme-gen restore` can undo it.
+// The file is replaced atomically. Nothing is written when the content is
+// unchanged.
+func writeFile(path string, content []byte) error {
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, content) {
+		return nil
+	}
+	if err := backupFile(path); err != nil {
+		return err
+	}
+	if err := fileutil.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// rollbackFile atomically puts content back into path if the file no
+// longer holds it
+func rollbackFile(path string, content []byte) error {
+	if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, content) {
+		return nil
+	}
+	return fileutil.WriteFile(path, content, 0644)
+}
+
+// backupName is the backup of the running command. Every file the command
+// rewrites is saved into it, so that one restore undoes the whole command.
+var backupName string
+
+// backupFile saves the current content of path, if any, into the backup of
+// the running command and reports where the backup went when it is created
+func backupFile(path string) error {
+	name, err := backup.Save(".", backupName, path)
+	if err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	if name != "" && backupName == "" {
+		msg := i18n.Get()
+		fmt.Println(ui.Info(fmt.Sprintf(msg.BackupSaved, filepath.Join(backup.Dir, name))))
+	}
+	backupName = name
+	return nil
+}
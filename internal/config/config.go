@@ -1,18 +1,31 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 const ConfigFileName = ".readme-gen.yaml"
 
+// WorkDir is the project directory holding readme-gen's own files
+// (backups, user templates). Backups are left out of the structure tree.
+const WorkDir = ".readme-gen"
+
 // Config represents the configuration for readme-gen
 type Config struct {
 	Structure StructureConfig `yaml:"structure"`
 	AI        AIConfig        `yaml:"ai"`
+	Badges    BadgesConfig    `yaml:"badges"`
+	Env       EnvConfig       `yaml:"env"`
+	// Descriptions maps relative paths to tree comments
+	// Takes precedence over comments derived from DescriptionSources
+	Descriptions map[string]Description `yaml:"descriptions"`
 }
 
 // StructureConfig configures directory structure scanning
@@ -24,6 +37,45 @@ type StructureConfig struct {
 	Patterns []string `yaml:"patterns"`
 	// MaxDepth limits directory traversal depth (0 = unlimited)
 	MaxDepth int `yaml:"max_depth"`
+	// MaxChildren limits the number of children rendered per directory (0 = unlimited)
+	// Remaining children are summarized with an elision line
+	MaxChildren int `yaml:"max_children"`
+	// Files includes files in the tree, not only directories (default: false)
+	Files bool `yaml:"files"`
+	// Hidden includes dot-prefixed entries in the tree (default: true)
+	Hidden bool `yaml:"hidden"`
+	// Sort selects the sort order of entries: name, case-insensitive or natural (default: name)
+	Sort string `yaml:"sort"`
+	// DirsFirst lists directories before files when files are shown
+	DirsFirst bool `yaml:"dirs_first"`
+	// Pin lists relative paths that always appear first among their siblings, in order
+	Pin []string `yaml:"pin"`
+	// DescriptionSources derives directory comments from project files, tried in order
+	// Available sources: file, godoc, readme, package_json (default: none)
+	DescriptionSources []string `yaml:"description_sources"`
+	// Heading identifies the structure block by the first code block under this
+	// heading (e.g. "## Structure") instead of HTML comment markers
+	Heading string `yaml:"heading"`
+	// CommentWidth wraps structure comments so lines stay within this width (0 = no wrapping)
+	CommentWidth int `yaml:"comment_width"`
+	// Paths holds per-subtree overrides keyed by relative path (e.g. "internal")
+	// Settings apply to the directory and everything below it
+	Paths map[string]PathConfig `yaml:"paths"`
+}
+
+// PathConfig configures settings for a subtree
+// Unset fields inherit from the parent path or the global settings
+type PathConfig struct {
+	// MaxDepth limits the number of levels shown below this path (0 = inherit)
+	MaxDepth int `yaml:"max_depth"`
+	// MaxChildren overrides StructureConfig.MaxChildren for this subtree
+	MaxChildren int `yaml:"max_children"`
+	// Files overrides StructureConfig.Files for this subtree
+	Files *bool `yaml:"files"`
+	// Hidden overrides StructureConfig.Hidden for this subtree
+	Hidden *bool `yaml:"hidden"`
+	// Collapse shows the directory itself but none of its contents
+	Collapse bool `yaml:"collapse"`
 }
 
 // AIConfig configures AI generation settings
@@ -34,6 +86,93 @@ type AIConfig struct {
 
 const DefaultAITimeout = 120
 
+// BadgesConfig configures the badges section
+type BadgesConfig struct {
+	// Include lists the badges to show, in order (default: every badge that applies)
+	// Available badges: license, go-reference, go-report-card, npm, ci, release
+	Include []string `yaml:"include"`
+	// Exclude lists badges to leave out
+	Exclude []string `yaml:"exclude"`
+	// Workflows lists the workflow files shown as CI badges (e.g. "ci.yml")
+	// Default: workflows triggered by pull_request
+	Workflows []string `yaml:"workflows"`
+	// Custom badges are added after the detected ones
+	Custom []CustomBadge `yaml:"custom"`
+}
+
+// CustomBadge is a badge added to the badges section as is
+type CustomBadge struct {
+	// Label is the alt text of the image
+	Label string `yaml:"label"`
+	// Image is the URL of the badge image
+	Image string `yaml:"image"`
+	// Link is the URL the badge links to (optional)
+	Link string `yaml:"link"`
+}
+
+// EnvConfig configures the env section
+type EnvConfig struct {
+	// Files lists the example env files to document, in order
+	// (default: .env.example, .env.sample)
+	Files []string `yaml:"files"`
+	// ScanGo also documents variables read with os.Getenv or os.LookupEnv
+	// in the Go sources (default: false)
+	ScanGo bool `yaml:"scan_go"`
+}
+
+// DefaultEnvFiles are the example env files documented by default
+var DefaultEnvFiles = []string{".env.example", ".env.sample"}
+
+// GetFiles returns the env files to document
+// Returns DefaultEnvFiles if none are set
+func (c *EnvConfig) GetFiles() []string {
+	if len(c.Files) == 0 {
+		return DefaultEnvFiles
+	}
+	return c.Files
+}
+
+// Badges for BadgesConfig.Include and Exclude
+const (
+	// BadgeLicense shows the SPDX license detected from the license file
+	BadgeLicense = "license"
+	// BadgeGoReference links to the package documentation on pkg.go.dev
+	BadgeGoReference = "go-reference"
+	// BadgeGoReportCard links to the Go Report Card of the module
+	BadgeGoReportCard = "go-report-card"
+	// BadgeNpm shows the version published to npm
+	BadgeNpm = "npm"
+	// BadgeCI shows the status of GitHub Actions workflows
+	BadgeCI = "ci"
+	// BadgeRelease shows the latest GitHub release
+	BadgeRelease = "release"
+)
+
+// AllBadges lists every badge in the default order
+var AllBadges = []string{BadgeLicense, BadgeGoReference, BadgeGoReportCard, BadgeNpm, BadgeCI, BadgeRelease}
+
+// Sort orders for StructureConfig.Sort
+const (
+	// SortName sorts entries by raw byte order
+	SortName = "name"
+	// SortCaseInsensitive sorts entries ignoring case
+	SortCaseInsensitive = "case-insensitive"
+	// SortNatural sorts entries ignoring case with numbers compared by value (v2 < v10)
+	SortNatural = "natural"
+)
+
+// Description sources for StructureConfig.DescriptionSources
+const (
+	// SourceFile reads a .readme-gen-description file in the directory
+	SourceFile = "file"
+	// SourceGoDoc uses the first sentence of the Go package comment
+	SourceGoDoc = "godoc"
+	// SourceReadme uses the first paragraph of a README in the directory
+	SourceReadme = "readme"
+	// SourcePackageJSON uses the description field of package.json
+	SourcePackageJSON = "package_json"
+)
+
 // Default returns the default configuration
 func Default() *Config {
 	return &Config{
@@ -41,6 +180,7 @@ func Default() *Config {
 			UseGitignore: true,
 			Patterns:     []string{},
 			MaxDepth:     0,
+			Hidden:       true,
 		},
 		AI: AIConfig{
 			Timeout: DefaultAITimeout,
@@ -75,9 +215,76 @@ func Load(root string) (*Config, error) {
 		return nil, err
 	}
 
+	cfg.Structure.normalizePaths()
+	cfg.Descriptions = normalizeDescriptions(cfg.Descriptions)
+	if err := cfg.Structure.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", ConfigFileName, err)
+	}
+	if err := cfg.Badges.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", ConfigFileName, err)
+	}
+
 	return cfg, nil
 }
 
+// validate checks structure settings that cannot be expressed by YAML types
+func (c *StructureConfig) validate() error {
+	switch c.Sort {
+	case "", SortName, SortCaseInsensitive, SortNatural:
+	default:
+		return fmt.Errorf("unknown sort order %q (expected %s, %s or %s)", c.Sort, SortName, SortCaseInsensitive, SortNatural)
+	}
+
+	for _, source := range c.DescriptionSources {
+		switch source {
+		case SourceFile, SourceGoDoc, SourceReadme, SourcePackageJSON:
+		default:
+			return fmt.Errorf("unknown description source %q (expected %s, %s, %s or %s)", source, SourceFile, SourceGoDoc, SourceReadme, SourcePackageJSON)
+		}
+	}
+
+	return nil
+}
+
+// validate checks that only known badges are listed
+func (c *BadgesConfig) validate() error {
+	for _, name := range append(append([]string{}, c.Include...), c.Exclude...) {
+		if !slices.Contains(AllBadges, name) {
+			return fmt.Errorf("unknown badge %q (expected one of %s)", name, strings.Join(AllBadges, ", "))
+		}
+	}
+	for _, badge := range c.Custom {
+		if badge.Image == "" {
+			return fmt.Errorf("custom badge %q has no image", badge.Label)
+		}
+	}
+	return nil
+}
+
+// normalizePaths cleans path keys and pins so they can be compared with relative paths
+// produced by the scanner ("./db/migrations/" -> "db/migrations")
+func (c *StructureConfig) normalizePaths() {
+	for i, p := range c.Pin {
+		c.Pin[i] = NormalizePath(p)
+	}
+	if len(c.Paths) == 0 {
+		return
+	}
+	paths := make(map[string]PathConfig, len(c.Paths))
+	for key, pc := range c.Paths {
+		paths[NormalizePath(key)] = pc
+	}
+	c.Paths = paths
+}
+
+// NormalizePath converts a user-supplied path to the slash-separated,
+// root-relative form used for matching
+func NormalizePath(p string) string {
+	p = strings.TrimSpace(filepath.ToSlash(p))
+	p = path.Clean("/" + p)
+	return strings.TrimPrefix(p, "/")
+}
+
 // ParsePatterns separates patterns into exclude and include lists
 // Patterns starting with ! are include patterns
 func (c *StructureConfig) ParsePatterns() (excludes, includes []string) {
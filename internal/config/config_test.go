@@ -139,3 +139,97 @@ ai:
 		t.Errorf("expected AI.Timeout to be 300, got %d", cfg.AI.Timeout)
 	}
 }
+
+func TestLoad_WithPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configContent := `structure:
+  max_children: 20
+  paths:
+    ./db/migrations/:
+      max_children: 5
+`
+	err := os.WriteFile(filepath.Join(tmpDir, ConfigFileName), []byte(configContent), 0644)
+	if err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Structure.MaxChildren != 20 {
+		t.Errorf("expected MaxChildren to be 20, got %d", cfg.Structure.MaxChildren)
+	}
+	pc, ok := cfg.Structure.Paths["db/migrations"]
+	if !ok {
+		t.Fatalf("expected normalized key db/migrations, got %v", cfg.Structure.Paths)
+	}
+	if pc.MaxChildren != 5 {
+		t.Errorf("expected MaxChildren to be 5, got %d", pc.MaxChildren)
+	}
+}
+
+func TestLoad_WithPathOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configContent := `structure:
+  max_depth: 1
+  paths:
+    internal/:
+      max_depth: 3
+      files: true
+    web:
+      hidden: false
+      collapse: true
+`
+	err := os.WriteFile(filepath.Join(tmpDir, ConfigFileName), []byte(configContent), 0644)
+	if err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if !cfg.Structure.Hidden {
+		t.Error("expected global Hidden to keep its default of true")
+	}
+
+	internal := cfg.Structure.Paths["internal"]
+	if internal.MaxDepth != 3 {
+		t.Errorf("expected internal MaxDepth to be 3, got %d", internal.MaxDepth)
+	}
+	if internal.Files == nil || !*internal.Files {
+		t.Error("expected internal Files to be true")
+	}
+	if internal.Hidden != nil {
+		t.Error("expected internal Hidden to be unset")
+	}
+
+	web := cfg.Structure.Paths["web"]
+	if web.Hidden == nil || *web.Hidden {
+		t.Error("expected web Hidden to be false")
+	}
+	if !web.Collapse {
+		t.Error("expected web Collapse to be true")
+	}
+}
+
+func TestLoad_InvalidSort(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configContent := `structure:
+  sort: random
+`
+	err := os.WriteFile(filepath.Join(tmpDir, ConfigFileName), []byte(configContent), 0644)
+	if err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	if _, err := Load(tmpDir); err == nil {
+		t.Error("expected error for unknown sort order")
+	}
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hulk510/readme-gen/internal/fileutil"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultLangKey is the YAML key for the language-independent text
+const defaultLangKey = "default"
+
+// Description is the comment for a path, optionally localized.
+// In YAML it is either a plain string or a map of language to text:
+//
+//	descriptions:
+//	  cmd: CLI entry point
+//	  internal:
+//	    default: Internal packages
+//	    ja: 内部パッケージ
+type Description map[string]string
+
+// Text returns the description for lang, falling back to the default
+// text and then to English
+func (d Description) Text(lang string) string {
+	for _, key := range []string{lang, "", "en"} {
+		if text := d[key]; text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// UnmarshalYAML accepts a plain string or a language map
+func (d *Description) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*d = Description{"": value.Value}
+		return nil
+	}
+
+	var localized map[string]string
+	if err := value.Decode(&localized); err != nil {
+		return fmt.Errorf("description must be a string or a map of language to text: %w", err)
+	}
+
+	result := make(Description, len(localized))
+	for lang, text := range localized {
+		if lang == defaultLangKey {
+			lang = ""
+		}
+		result[lang] = text
+	}
+	*d = result
+	return nil
+}
+
+// MarshalYAML writes a plain string when only the default text is set
+func (d Description) MarshalYAML() (interface{}, error) {
+	if text, ok := d[""]; ok && len(d) == 1 {
+		return text, nil
+	}
+
+	result := make(map[string]string, len(d))
+	for lang, text := range d {
+		if lang == "" {
+			lang = defaultLangKey
+		}
+		result[lang] = text
+	}
+	return result, nil
+}
+
+// DescriptionsFor resolves the configured descriptions for lang
+func (c *Config) DescriptionsFor(lang string) map[string]string {
+	result := make(map[string]string, len(c.Descriptions))
+	for p, d := range c.Descriptions {
+		if text := d.Text(lang); text != "" {
+			result[p] = text
+		}
+	}
+	return result
+}
+
+// normalizeDescriptions cleans description keys like StructureConfig.Paths
+func normalizeDescriptions(descriptions map[string]Description) map[string]Description {
+	if len(descriptions) == 0 {
+		return descriptions
+	}
+	result := make(map[string]Description, len(descriptions))
+	for key, d := range descriptions {
+		result[NormalizePath(key)] = d
+	}
+	return result
+}
+
+// SaveDescriptions writes the descriptions section of .readme-gen.yaml in
+// the given directory, leaving the rest of the file (including comments) intact
+func SaveDescriptions(root string, descriptions map[string]Description) error {
+	configPath := filepath.Join(root, ConfigFileName)
+
+	var doc yaml.Node
+	data, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	top := doc.Content[0]
+	if top.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: top level must be a mapping", ConfigFileName)
+	}
+
+	var value yaml.Node
+	if err := value.Encode(descriptions); err != nil {
+		return err
+	}
+
+	replaced := false
+	for i := 0; i+1 < len(top.Content); i += 2 {
+		if top.Content[i].Value == "descriptions" {
+			top.Content[i+1] = &value
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: "descriptions"}
+		top.Content = append(top.Content, key, &value)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	return fileutil.WriteFile(configPath, buf.Bytes(), 0644)
+}
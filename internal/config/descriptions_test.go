@@ -0,0 +1,112 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad_WithDescriptions(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configContent := `descriptions:
+  ./cmd/: CLI entry point
+  internal:
+    default: Internal packages
+    ja: 内部パッケージ
+  web:
+    ja: フロントエンド
+`
+	err := os.WriteFile(filepath.Join(tmpDir, ConfigFileName), []byte(configContent), 0644)
+	if err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	en := cfg.DescriptionsFor("en")
+	if en["cmd"] != "CLI entry point" {
+		t.Errorf("expected normalized cmd description, got %q", en["cmd"])
+	}
+	if en["internal"] != "Internal packages" {
+		t.Errorf("expected default internal description, got %q", en["internal"])
+	}
+	if _, ok := en["web"]; ok {
+		t.Error("expected web to have no English description")
+	}
+
+	ja := cfg.DescriptionsFor("ja")
+	if ja["internal"] != "内部パッケージ" {
+		t.Errorf("expected Japanese internal description, got %q", ja["internal"])
+	}
+	if ja["cmd"] != "CLI entry point" {
+		t.Errorf("expected fallback to default text, got %q", ja["cmd"])
+	}
+}
+
+func TestSaveDescriptions_PreservesConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configContent := `# project settings
+structure:
+  max_depth: 2 # keep it short
+descriptions:
+  old: Old entry
+`
+	configPath := filepath.Join(tmpDir, ConfigFileName)
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	err := SaveDescriptions(tmpDir, map[string]Description{
+		"cmd":      {"": "CLI entry point"},
+		"internal": {"": "Internal packages", "ja": "内部パッケージ"},
+	})
+	if err != nil {
+		t.Fatalf("SaveDescriptions failed: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	content := string(data)
+
+	for _, want := range []string{"# project settings", "max_depth: 2 # keep it short", "cmd: CLI entry point", "default: Internal packages", "ja: 内部パッケージ"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected config to contain %q, got:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "old:") {
+		t.Errorf("expected descriptions to be replaced, got:\n%s", content)
+	}
+
+	cfg, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Structure.MaxDepth != 2 || len(cfg.Descriptions) != 2 {
+		t.Errorf("unexpected config after save: %+v", cfg)
+	}
+}
+
+func TestSaveDescriptions_NoFile(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	err := SaveDescriptions(tmpDir, map[string]Description{"cmd": {"": "CLI entry point"}})
+	if err != nil {
+		t.Fatalf("SaveDescriptions failed: %v", err)
+	}
+
+	cfg, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.DescriptionsFor("en")["cmd"] != "CLI entry point" {
+		t.Errorf("unexpected descriptions: %v", cfg.Descriptions)
+	}
+}
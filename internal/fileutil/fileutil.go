@@ -0,0 +1,44 @@
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WriteFile atomically replaces the file at name with data. The content is
+// written to a temporary file in the same directory, synced and renamed over
+// the target, so readers and interrupted runs never see a partially written
+// file. An existing file keeps its permissions; perm is used for new files.
+// Symlinks are followed so that the link target is replaced, not the link.
+func WriteFile(name string, data []byte, perm os.FileMode) error {
+	if resolved, err := filepath.EvalSymlinks(name); err == nil {
+		name = resolved
+	}
+	if info, err := os.Stat(name); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpName, name)
+}
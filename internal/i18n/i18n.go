@@ -36,8 +36,12 @@ type Messages struct {
 	RunInitHint        string
 
 	// Template options
-	TemplateOSS     string
-	TemplateGeneral string
+	TemplateOSS      string
+	TemplateGeneral  string
+	TemplateCLI      string
+	TemplateLibrary  string
+	TemplateService  string
+	TemplateMonorepo string
 
 	// Success messages
 	CreatedReadme string
@@ -46,6 +50,7 @@ type Messages struct {
 
 	// Structure markers info
 	MarkersInfo  string
+	HeadingInfo  string
 	RunLaterHint string
 
 	// Claude Code integration
@@ -57,6 +62,71 @@ type Messages struct {
 	ClaudeCodeNotFound    string
 	AIGenerationFailed    string
 
+	// Descriptions
+	ImportedDescriptions    string
+	NoCommentsFound         string
+	AllDirectoriesDescribed string
+	DescriptionsMissing     string
+	DescriptionCoverage     string
+	CoverageBelowMinimum    string
+	CommentStale            string
+	CommentMoved            string
+	DuplicateEntry          string
+
+	// Marker insertion
+	StructureHeading    string
+	NoStructureSection  string
+	MarkersAlreadyExist string
+	InsertedMarkers     string
+
+	// Adopt mode
+	AdoptPreview  string
+	AdoptConfirm  string
+	AdoptNothing  string
+	AdoptedReadme string
+
+	// Formatting
+	FormattedReadme  string
+	AlreadyFormatted string
+	NotFormatted     string
+	RunFmtHint       string
+
+	// Backups
+	BackupSaved         string
+	UseForceToOverwrite string
+	RestoredFile        string
+	RestoredBackup      string
+	NoBackups           string
+	AvailableBackups    string
+	RolledBack          string
+	RollbackFailed      string
+
+	// Templates
+	NoTemplates              string
+	EjectedTemplate          string
+	TemplateExistsHint       string
+	TemplateLintOK           string
+	TemplateLintFailed       string
+	TemplateMissingStructure string
+	TemplateUnknownField     string
+
+	// README templates
+	NoReadmeTemplates     string
+	RenderedReadme        string
+	ReadmeMatchesTemplate string
+	ReadmeDiffers         string
+	ReadmeNotGenerated    string
+	RunSyncHint           string
+
+	// Managed sections
+	NoManagedSections    string
+	AddSectionHint       string
+	SectionUpdated       string
+	SectionUpToDate      string
+	SectionOutOfSync     string
+	UnknownSection       string
+	RunUpdateSectionHint string
+
 	// Steps
 	StepLanguage    string
 	StepTemplate    string
@@ -81,21 +151,26 @@ var messages = map[Language]Messages{
 		UpdatingStructure:  "Updating structure...",
 		ChangesDetected:    "Changes detected",
 		NoMarkersFound:     "No structure markers found in README.md",
-		AddMarkersHint:     "Add markers with `readme-gen init` or manually",
+		AddMarkersHint:     "Add markers with `readme-gen structure --insert`, `readme-gen init` or manually",
 		StructureUpToDate:  "README.md is up to date",
 		StructureOutOfSync: "Structure out of sync!",
 		RunUpdateHint:      "Run `readme-gen structure --update` to fix",
 		ReadmeNotFound:     "README.md not found",
 		RunInitHint:        "Run `readme-gen init` first",
 
-		TemplateOSS:     "oss - MIT license, contributing guide",
-		TemplateGeneral: "general - For personal and team projects",
+		TemplateOSS:      "oss - MIT license, contributing guide",
+		TemplateGeneral:  "general - For personal and team projects",
+		TemplateCLI:      "cli - Command-line tool with usage and commands",
+		TemplateLibrary:  "library - Go/TS library with import snippet and API docs",
+		TemplateService:  "service - Web service with local setup, env vars and Docker",
+		TemplateMonorepo: "monorepo - Index of the packages in the repository",
 
 		CreatedReadme: "Created README.md",
 		CreatedSkills: "Created .claude/skills/readme.md",
 		UpdatedReadme: "README.md updated!",
 
 		MarkersInfo:  "Structure will be placed between markers",
+		HeadingInfo:  "Structure will be placed in the first code block under %s (structure.heading)",
 		RunLaterHint: "Run `readme-gen structure` to update later",
 
 		ClaudeCodeIntegration: "Claude Code integration",
@@ -106,6 +181,63 @@ var messages = map[Language]Messages{
 		ClaudeCodeNotFound:    "Claude Code not found. Skipping AI generation.",
 		AIGenerationFailed:    "AI generation failed",
 
+		ImportedDescriptions:    "Imported %d descriptions into .readme-gen.yaml",
+		NoCommentsFound:         "No comments found in the structure section",
+		AllDirectoriesDescribed: "All directories have descriptions",
+		DescriptionsMissing:     "%d directories have no description:",
+		DescriptionCoverage:     "Description coverage: %.1f%% (%d/%d)",
+		CoverageBelowMinimum:    "Description coverage is below the required %.1f%%",
+		CommentStale:            "comment on %s refers to a path that no longer exists (# %s)",
+		CommentMoved:            "comment on %s moves to line %d",
+		DuplicateEntry:          "%s is listed twice (first on line %d)",
+
+		StructureHeading:    "Structure",
+		NoStructureSection:  "No code block found under the \"%s\" heading in README.md",
+		MarkersAlreadyExist: "Structure markers already exist. Run `readme-gen structure --update` instead",
+		InsertedMarkers:     "Inserted structure markers into README.md",
+
+		AdoptPreview:  "The following sections will be added to README.md",
+		AdoptConfirm:  "Add these sections?",
+		AdoptNothing:  "README.md already has all template sections",
+		AdoptedReadme: "Added %d sections to README.md",
+
+		FormattedReadme:  "Formatted structure comments in README.md",
+		AlreadyFormatted: "Structure comments are already formatted",
+		NotFormatted:     "Structure comments are not formatted",
+		RunFmtHint:       "Run `readme-gen fmt` to fix",
+
+		BackupSaved:         "Backup saved to %s (undo with `readme-gen restore`)",
+		UseForceToOverwrite: "Refusing to overwrite in non-interactive mode. Use --force to overwrite or --adopt to keep it",
+		RestoredFile:        "Restored %s",
+		RestoredBackup:      "Restored backup %s",
+		NoBackups:           "No backups found",
+		AvailableBackups:    "Available backups",
+		RolledBack:          "%s was restored to its previous content",
+		RollbackFailed:      "%s could not be rolled back (%v). Run `readme-gen restore` to recover it",
+
+		NoTemplates:              "No templates to lint in .readme-gen/templates or the user template directory",
+		EjectedTemplate:          "Created %s",
+		TemplateExistsHint:       "Template already exists. Use --force to replace it",
+		TemplateLintOK:           "%s: OK",
+		TemplateLintFailed:       "%d template problems found",
+		TemplateMissingStructure: "does not reference .Structure, so the README will have no structure section",
+		TemplateUnknownField:     "unknown field .%s",
+
+		NoReadmeTemplates:     "No README.md.tmpl found in the project root",
+		RenderedReadme:        "Generated %s from %s",
+		ReadmeMatchesTemplate: "%s is up to date with %s",
+		ReadmeDiffers:         "%s differs from %s (first difference on line %d)",
+		ReadmeNotGenerated:    "%s has not been generated from %s",
+		RunSyncHint:           "Run `readme-gen sync` to regenerate",
+
+		NoManagedSections:    "No generated sections found in README.md or its translations",
+		AddSectionHint:       "Add <!-- readme-gen:badges:start --> and <!-- readme-gen:badges:end --> where the section should go",
+		SectionUpdated:       "%s: updated %s section",
+		SectionUpToDate:      "%s: %s section is up to date",
+		SectionOutOfSync:     "%s: %s section is out of date",
+		UnknownSection:       "Unknown section '%s' left unchanged",
+		RunUpdateSectionHint: "Run `readme-gen update` to regenerate",
+
 		StepLanguage:    "Language",
 		StepTemplate:    "Template",
 		StepProjectInfo: "Project Info",
@@ -126,21 +258,26 @@ var messages = map[Language]Messages{
 		UpdatingStructure:  "構造を更新中...",
 		ChangesDetected:    "変更を検出",
 		NoMarkersFound:     "README.mdに構造マーカーが見つかりません",
-		AddMarkersHint:     "`readme-gen init`またはマーカーを手動で追加してください",
+		AddMarkersHint:     "`readme-gen structure --insert`、`readme-gen init`またはマーカーを手動で追加してください",
 		StructureUpToDate:  "README.mdは最新です",
 		StructureOutOfSync: "構造が同期されていません！",
 		RunUpdateHint:      "`readme-gen structure --update`で修正してください",
 		ReadmeNotFound:     "README.mdが見つかりません",
 		RunInitHint:        "先に`readme-gen init`を実行してください",
 
-		TemplateOSS:     "oss - MITライセンス、コントリビューションガイド付き",
-		TemplateGeneral: "general - 個人・チームプロジェクト向け",
+		TemplateOSS:      "oss - MITライセンス、コントリビューションガイド付き",
+		TemplateGeneral:  "general - 個人・チームプロジェクト向け",
+		TemplateCLI:      "cli - 使い方とコマンド一覧付きのCLIツール",
+		TemplateLibrary:  "library - import例とAPIドキュメント付きのGo/TSライブラリ",
+		TemplateService:  "service - ローカル起動、環境変数、Docker付きのWebサービス",
+		TemplateMonorepo: "monorepo - リポジトリ内のパッケージ一覧",
 
 		CreatedReadme: "README.mdを作成しました",
 		CreatedSkills: ".claude/skills/readme.mdを作成しました",
 		UpdatedReadme: "README.mdを更新しました！",
 
 		MarkersInfo:  "構造はマーカー間に配置されます",
+		HeadingInfo:  "構造は %s の下にある最初のコードブロックに配置されます（structure.heading）",
 		RunLaterHint: "`readme-gen structure`で後から更新できます",
 
 		ClaudeCodeIntegration: "Claude Code連携",
@@ -151,6 +288,63 @@ var messages = map[Language]Messages{
 		ClaudeCodeNotFound:    "Claude Codeが見つかりません。AI生成をスキップします。",
 		AIGenerationFailed:    "AI生成に失敗しました",
 
+		ImportedDescriptions:    "%d件の説明を.readme-gen.yamlに取り込みました",
+		NoCommentsFound:         "構造セクションにコメントが見つかりません",
+		AllDirectoriesDescribed: "すべてのディレクトリに説明があります",
+		DescriptionsMissing:     "説明のないディレクトリが%d件あります:",
+		DescriptionCoverage:     "説明カバレッジ: %.1f%% (%d/%d)",
+		CoverageBelowMinimum:    "説明カバレッジが必要な%.1f%%を下回っています",
+		CommentStale:            "%s のコメントは存在しないパスを指しています (# %s)",
+		CommentMoved:            "%s のコメントは%d行目に移動します",
+		DuplicateEntry:          "%s が重複しています（最初は%d行目）",
+
+		StructureHeading:    "構造",
+		NoStructureSection:  "README.mdの「%s」見出しの下にコードブロックが見つかりません",
+		MarkersAlreadyExist: "構造マーカーは既にあります。`readme-gen structure --update`を使用してください",
+		InsertedMarkers:     "README.mdに構造マーカーを挿入しました",
+
+		AdoptPreview:  "README.mdに以下のセクションを追加します",
+		AdoptConfirm:  "これらのセクションを追加しますか？",
+		AdoptNothing:  "README.mdにはテンプレートの全セクションが揃っています",
+		AdoptedReadme: "README.mdに%d件のセクションを追加しました",
+
+		FormattedReadme:  "README.mdの構造コメントを整形しました",
+		AlreadyFormatted: "構造コメントは整形済みです",
+		NotFormatted:     "構造コメントが整形されていません",
+		RunFmtHint:       "`readme-gen fmt`で修正してください",
+
+		BackupSaved:         "バックアップを%sに保存しました（`readme-gen restore`で元に戻せます）",
+		UseForceToOverwrite: "非対話モードでは上書きしません。上書きするには--force、既存の内容を残すには--adoptを指定してください",
+		RestoredFile:        "%sを復元しました",
+		RestoredBackup:      "バックアップ%sを復元しました",
+		NoBackups:           "バックアップがありません",
+		AvailableBackups:    "利用可能なバックアップ",
+		RolledBack:          "%sを変更前の内容に戻しました",
+		RollbackFailed:      "%sを元に戻せませんでした（%v）。`readme-gen restore`で復元してください",
+
+		NoTemplates:              ".readme-gen/templatesとユーザーテンプレートディレクトリにチェック対象のテンプレートがありません",
+		EjectedTemplate:          "%sを作成しました",
+		TemplateExistsHint:       "テンプレートは既に存在します。置き換えるには--forceを指定してください",
+		TemplateLintOK:           "%s: OK",
+		TemplateLintFailed:       "テンプレートに%d件の問題があります",
+		TemplateMissingStructure: ".Structureを参照していないため、READMEに構造セクションが作られません",
+		TemplateUnknownField:     "不明なフィールド .%s",
+
+		NoReadmeTemplates:     "プロジェクトルートにREADME.md.tmplがありません",
+		RenderedReadme:        "%sを%sから生成しました",
+		ReadmeMatchesTemplate: "%sは%sと一致しています",
+		ReadmeDiffers:         "%sが%sと一致しません（最初の差分: %d行目）",
+		ReadmeNotGenerated:    "%sが%sから生成されていません",
+		RunSyncHint:           "`readme-gen sync`で再生成してください",
+
+		NoManagedSections:    "README.mdとその翻訳に生成セクションが見つかりません",
+		AddSectionHint:       "セクションを置く場所に <!-- readme-gen:badges:start --> と <!-- readme-gen:badges:end --> を追加してください",
+		SectionUpdated:       "%s: %sセクションを更新しました",
+		SectionUpToDate:      "%s: %sセクションは最新です",
+		SectionOutOfSync:     "%s: %sセクションが最新ではありません",
+		UnknownSection:       "不明なセクション'%s'は変更しません",
+		RunUpdateSectionHint: "`readme-gen update`で再生成してください",
+
 		StepLanguage:    "言語",
 		StepTemplate:    "テンプレート",
 		StepProjectInfo: "プロジェクト情報",
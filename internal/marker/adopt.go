@@ -0,0 +1,143 @@
+package marker
+
+import (
+	"strings"
+)
+
+// Section is a second-level section of a Markdown document
+type Section struct {
+	// Title is the heading text
+	Title string
+	// Body is the full section text including the heading line
+	Body string
+}
+
+// Sections splits content into its second-level sections. Text before
+// the first "##" heading (title and introduction) is not included.
+func Sections(content string) []Section {
+	lines := strings.Split(content, "\n")
+
+	var starts []Heading
+	for _, h := range Headings(content) {
+		if h.Level == 2 {
+			starts = append(starts, h)
+		}
+	}
+
+	var sections []Section
+	for i, h := range starts {
+		end := len(lines)
+		if i+1 < len(starts) {
+			end = starts[i+1].Line
+		}
+		body := strings.TrimRight(strings.Join(lines[h.Line:end], "\n"), "\n ")
+		sections = append(sections, Section{Title: h.Title, Body: body})
+	}
+	return sections
+}
+
+// Adopt adds the sections of tmpl that content lacks, keeping everything
+// already in content. Missing sections are placed in template order before
+// the next template section that content already has, or at the end.
+// If content has a structure heading but no markers, the markers of tmpl
+// are inserted below that heading instead of adding a new section.
+// It returns the merged content and the titles of the added sections.
+func Adopt(content, tmpl string) (string, []string, error) {
+	return Target{}.Adopt(content, tmpl)
+}
+
+// Adopt adds the sections of tmpl that content lacks like the package-level
+// Adopt. In heading mode the structure section is added without markers,
+// and only the target heading counts as an existing structure heading.
+func (t Target) Adopt(content, tmpl string) (string, []string, error) {
+	headings := StructureHeadings
+	if t.Heading != "" {
+		headings = []string{t.Heading}
+	}
+
+	var added []string
+	templateSections := Sections(tmpl)
+
+	for i, section := range templateSections {
+		if strings.Contains(section.Body, MarkerStart) {
+			if t.hasBlock(content) {
+				continue
+			}
+			if _, ok := FindHeading(content, headings...); ok {
+				structure, _ := Extract(section.Body)
+				merged, err := t.Insert(content, structure, InsertOptions{})
+				if err != nil {
+					return "", nil, err
+				}
+				content = merged
+				added = append(added, section.Title)
+				continue
+			}
+			if t.Heading != "" {
+				section.Body = stripMarkers(section.Body)
+			}
+		}
+
+		if _, ok := FindHeading(content, section.Title); ok {
+			continue
+		}
+
+		content = insertSection(content, section.Body, templateSections[i+1:])
+		added = append(added, section.Title)
+	}
+
+	return content, added, nil
+}
+
+// hasBlock reports whether content already has a structure block: markers,
+// or a code block under the target heading in heading mode
+func (t Target) hasBlock(content string) bool {
+	if t.Heading == "" {
+		return strings.Contains(content, MarkerStart)
+	}
+	_, ok := t.Extract(content)
+	return ok
+}
+
+// stripMarkers removes the structure marker lines from body, keeping the
+// fenced block between them
+func stripMarkers(body string) string {
+	var kept []string
+	for _, line := range strings.Split(body, "\n") {
+		if trimmed := strings.TrimSpace(line); trimmed == MarkerStart || trimmed == MarkerEnd {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// insertSection places body before the first of following that exists in
+// content, or at the end of content
+func insertSection(content, body string, following []Section) string {
+	lines := strings.Split(content, "\n")
+	at := len(lines)
+	for _, next := range following {
+		if h, ok := FindHeading(content, next.Title); ok {
+			at = h.Line
+			break
+		}
+	}
+
+	before := lines[:at]
+	after := lines[at:]
+	for len(before) > 0 && strings.TrimSpace(before[len(before)-1]) == "" {
+		before = before[:len(before)-1]
+	}
+
+	section := strings.Split(body, "\n")
+	if len(before) > 0 {
+		section = append([]string{""}, section...)
+	}
+	if strings.TrimSpace(strings.Join(after, "")) != "" {
+		section = append(section, "")
+	} else {
+		after = []string{""}
+	}
+	return joinLines(before, section, after)
+}
@@ -0,0 +1,148 @@
+package marker
+
+import (
+	"strings"
+	"testing"
+)
+
+const adoptTemplate = `# app
+
+Description.
+
+## Structure
+
+` + MarkerStart + "\n```\n└── cmd/\n```\n" + MarkerEnd + `
+
+## Installation
+
+Install it.
+
+## Usage
+
+Use it.
+
+## License
+
+MIT
+`
+
+func TestSections(t *testing.T) {
+	sections := Sections(adoptTemplate)
+
+	titles := []string{"Structure", "Installation", "Usage", "License"}
+	if len(sections) != len(titles) {
+		t.Fatalf("Sections() returned %d sections, want %d", len(sections), len(titles))
+	}
+	for i, title := range titles {
+		if sections[i].Title != title {
+			t.Errorf("section %d title = %q, want %q", i, sections[i].Title, title)
+		}
+	}
+	if sections[1].Body != "## Installation\n\nInstall it." {
+		t.Errorf("unexpected section body: %q", sections[1].Body)
+	}
+}
+
+func TestAdopt(t *testing.T) {
+	existing := `# My App
+
+Hand-written intro.
+
+## Usage
+
+My own usage docs.
+`
+
+	merged, added, err := Adopt(existing, adoptTemplate)
+	if err != nil {
+		t.Fatalf("Adopt failed: %v", err)
+	}
+
+	if strings.Join(added, ",") != "Structure,Installation,License" {
+		t.Errorf("unexpected added sections: %v", added)
+	}
+
+	want := "# My App\n\nHand-written intro.\n\n## Structure\n\n" + Wrap("└── cmd/") +
+		"\n\n## Installation\n\nInstall it.\n\n## Usage\n\nMy own usage docs.\n\n## License\n\nMIT\n"
+	if merged != want {
+		t.Errorf("Adopt() =\n%s\nwant:\n%s", merged, want)
+	}
+
+	again, added, err := Adopt(merged, adoptTemplate)
+	if err != nil {
+		t.Fatalf("Adopt failed: %v", err)
+	}
+	if len(added) != 0 || again != merged {
+		t.Errorf("expected second Adopt to be a no-op, added %v", added)
+	}
+}
+
+func TestAdopt_ExistingStructureHeading(t *testing.T) {
+	existing := "# My App\n\n## Project structure\n\n```\ncmd/ - binaries\n```\n\n## Installation\n\nSteps.\n\n## Usage\n\nRun.\n\n## License\n\nApache\n"
+
+	merged, added, err := Adopt(existing, adoptTemplate)
+	if err != nil {
+		t.Fatalf("Adopt failed: %v", err)
+	}
+
+	if strings.Join(added, ",") != "Structure" {
+		t.Errorf("unexpected added sections: %v", added)
+	}
+	if !strings.Contains(merged, "## Project structure\n\n"+Wrap("└── cmd/")+"\n\n## Installation") {
+		t.Errorf("expected markers under existing heading, got:\n%s", merged)
+	}
+	if strings.Count(merged, "## ") != 4 {
+		t.Errorf("expected no new headings, got:\n%s", merged)
+	}
+}
+
+func TestTarget_Adopt_Heading(t *testing.T) {
+	target := Target{Heading: "## Structure"}
+	existing := "# My App\n\n## Usage\n\nMy own usage docs.\n"
+
+	merged, added, err := target.Adopt(existing, adoptTemplate)
+	if err != nil {
+		t.Fatalf("Adopt failed: %v", err)
+	}
+
+	if strings.Join(added, ",") != "Structure,Installation,License" {
+		t.Errorf("unexpected added sections: %v", added)
+	}
+	if strings.Contains(merged, "readme-gen:structure") {
+		t.Errorf("expected no markers in heading mode, got:\n%s", merged)
+	}
+	if !strings.Contains(merged, "## Structure\n\n```\n└── cmd/\n```\n\n## Installation") {
+		t.Errorf("expected heading and code block to be kept, got:\n%s", merged)
+	}
+	if structure, ok := target.Extract(merged); !ok || structure != "└── cmd/" {
+		t.Errorf("Extract() = %q, %v", structure, ok)
+	}
+
+	again, added, err := target.Adopt(merged, adoptTemplate)
+	if err != nil {
+		t.Fatalf("Adopt failed: %v", err)
+	}
+	if len(added) != 0 || again != merged {
+		t.Errorf("expected second Adopt to be a no-op, added %v", added)
+	}
+}
+
+func TestTarget_Adopt_HeadingWithoutBlock(t *testing.T) {
+	target := Target{Heading: "## Structure"}
+	existing := "# My App\n\n## Structure\n\nSee below.\n\n## Installation\n\nSteps.\n\n## Usage\n\nRun.\n\n## License\n\nApache\n"
+
+	merged, added, err := target.Adopt(existing, adoptTemplate)
+	if err != nil {
+		t.Fatalf("Adopt failed: %v", err)
+	}
+
+	if strings.Join(added, ",") != "Structure" {
+		t.Errorf("unexpected added sections: %v", added)
+	}
+	if strings.Contains(merged, "readme-gen:structure") || strings.Count(merged, "## ") != 4 {
+		t.Errorf("expected a plain code block under the existing heading, got:\n%s", merged)
+	}
+	if structure, ok := target.Extract(merged); !ok || structure != "└── cmd/" {
+		t.Errorf("Extract() = %q, %v", structure, ok)
+	}
+}
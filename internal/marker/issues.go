@@ -0,0 +1,82 @@
+package marker
+
+import (
+	"strings"
+)
+
+// IssueKind classifies a problem with the comments of a structure tree
+type IssueKind int
+
+const (
+	// IssueStale is a comment on an entry that no longer exists
+	IssueStale IssueKind = iota
+	// IssueMoved is a comment on an entry that is now on a different line
+	IssueMoved
+	// IssueDuplicate is an entry listed more than once
+	IssueDuplicate
+)
+
+// Issue describes a comment problem found by CheckComments
+type Issue struct {
+	Kind IssueKind
+	// Entry is the affected entry in the README structure
+	Entry Entry
+	// OtherLine is the 0-based README line the entry moves to, counting
+	// wrapped comment lines (IssueMoved), or the line of its first
+	// occurrence in the README (IssueDuplicate)
+	OtherLine int
+}
+
+// CheckComments compares the README structure with the current structure
+// and reports comments that would be lost or end up on the wrong line,
+// as well as duplicate entries. Entries are compared by their position in
+// the tree, so the continuation lines of wrapped comments do not count as
+// moves. Issues are ordered by README line.
+func CheckComments(readme, current string) []Issue {
+	positions := make(map[string]int)
+	for i, e := range ParseTree(current) {
+		positions[e.Path] = i
+	}
+
+	entries := ParseTree(readme)
+	var issues []Issue
+	seen := make(map[string]int)
+	for i, e := range entries {
+		if first, ok := seen[e.Path]; ok {
+			issues = append(issues, Issue{Kind: IssueDuplicate, Entry: e, OtherLine: first})
+			continue
+		}
+		seen[e.Path] = e.Line
+
+		if e.Comment == "" {
+			continue
+		}
+
+		position, ok := positions[e.Path]
+		switch {
+		case !ok:
+			issues = append(issues, Issue{Kind: IssueStale, Entry: e})
+		case position != i:
+			issues = append(issues, Issue{Kind: IssueMoved, Entry: e, OtherLine: entryLine(readme, entries, position)})
+		}
+	}
+
+	return issues
+}
+
+// entryLine returns the README line of the entry at position, or the lines
+// after the end of the README for positions past its last entry
+func entryLine(readme string, entries []Entry, position int) int {
+	if position < len(entries) {
+		return entries[position].Line
+	}
+	return strings.Count(readme, "\n") + 1 + position - len(entries)
+}
+
+// DisplayPath returns the entry path with a trailing slash for directories
+func (e Entry) DisplayPath() string {
+	if e.IsDir {
+		return e.Path + "/"
+	}
+	return e.Path
+}
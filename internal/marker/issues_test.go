@@ -0,0 +1,77 @@
+package marker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckComments(t *testing.T) {
+	readme := `├── api/          # HTTP handlers
+├── cmd/          # CLI entry point
+├── internal/
+│   └── ui/       # Terminal UI
+└── cmd/`
+
+	current := `├── cmd/
+├── internal/
+│   ├── server/
+│   ├── tools/
+│   └── ui/
+└── web/`
+
+	issues := CheckComments(readme, current)
+
+	want := []struct {
+		kind  IssueKind
+		path  string
+		line  int
+		other int
+	}{
+		{IssueStale, "api", 0, 0},
+		{IssueMoved, "cmd", 1, 0},
+		{IssueMoved, "internal/ui", 3, 4},
+		{IssueDuplicate, "cmd", 4, 1},
+	}
+
+	if len(issues) != len(want) {
+		t.Fatalf("CheckComments() returned %d issues, want %d: %+v", len(issues), len(want), issues)
+	}
+	for i, w := range want {
+		got := issues[i]
+		if got.Kind != w.kind || got.Entry.Path != w.path || got.Entry.Line != w.line || got.OtherLine != w.other {
+			t.Errorf("issue %d = {%v %s %d %d}, want %+v", i, got.Kind, got.Entry.Path, got.Entry.Line, got.OtherLine, w)
+		}
+	}
+}
+
+func TestCheckComments_InSync(t *testing.T) {
+	readme := `├── cmd/   # CLI entry point
+└── internal/`
+
+	if issues := CheckComments(readme, StripComments(readme)); len(issues) != 0 {
+		t.Errorf("expected no issues, got %+v", issues)
+	}
+}
+
+func TestCheckComments_WrappedComments(t *testing.T) {
+	readme := Format("├── aaa/  # A long description that wraps onto more than one line\n├── bbb/\n└── ccc/  # Third", 30)
+	if !strings.Contains(readme, "\n│") {
+		t.Fatalf("expected wrapped comments, got:\n%s", readme)
+	}
+
+	if issues := CheckComments(readme, "├── aaa/\n├── bbb/\n├── ccc/\n└── ddd/"); len(issues) != 0 {
+		t.Errorf("expected no issues for an unmoved entry, got %+v", issues)
+	}
+
+	issues := CheckComments(readme, "├── aaa/\n├── ccc/\n└── ddd/")
+	lines := strings.Split(readme, "\n")
+	if len(issues) != 1 || issues[0].Kind != IssueMoved || issues[0].Entry.Path != "ccc" {
+		t.Fatalf("expected ccc to move, got %+v", issues)
+	}
+	if got := lines[issues[0].Entry.Line]; !strings.Contains(got, "ccc/") {
+		t.Errorf("Entry.Line %d points at %q", issues[0].Entry.Line, got)
+	}
+	if got := lines[issues[0].OtherLine]; !strings.Contains(got, "bbb/") {
+		t.Errorf("OtherLine %d points at %q, want the line of bbb/", issues[0].OtherLine, got)
+	}
+}
@@ -0,0 +1,92 @@
+package marker
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Managed sections are blocks of README.md that readme-gen regenerates,
+// delimited by <!-- readme-gen:<name>:start --> and <!-- readme-gen:<name>:end -->.
+
+// StructureSection is the name of the managed section holding the structure
+const StructureSection = "structure"
+
+// managedStartRegex finds the start markers of managed sections
+var managedStartRegex = regexp.MustCompile(`<!-- readme-gen:([a-z0-9-]+):start -->`)
+
+// StartMarker returns the opening marker of the managed section name
+func StartMarker(name string) string {
+	return "<!-- readme-gen:" + name + ":start -->"
+}
+
+// EndMarker returns the closing marker of the managed section name
+func EndMarker(name string) string {
+	return "<!-- readme-gen:" + name + ":end -->"
+}
+
+// ManagedNames returns the names of the managed sections in content that
+// have both markers, in order of appearance
+func ManagedNames(content string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range managedStartRegex.FindAllStringSubmatch(content, -1) {
+		name := m[1]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if _, ok := ExtractManaged(content, name); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ExtractManaged returns the body of the managed section name without
+// surrounding blank lines
+func ExtractManaged(content, name string) (string, bool) {
+	start, end, ok := managedBounds(content, name)
+	if !ok {
+		return "", false
+	}
+	return strings.Trim(content[start:end], "\n"), true
+}
+
+// UpdateManaged replaces the body of the managed section name
+func UpdateManaged(content, name, body string) (string, error) {
+	start, end, ok := managedBounds(content, name)
+	if !ok {
+		return "", fmt.Errorf("markers for section '%s' not found in content", name)
+	}
+	return content[:start] + "\n" + managedBody(body) + content[end:], nil
+}
+
+// WrapManaged wraps body with the markers of the managed section name
+func WrapManaged(name, body string) string {
+	return StartMarker(name) + "\n" + managedBody(body) + EndMarker(name)
+}
+
+// managedBody returns body followed by a newline, or nothing for an empty body
+func managedBody(body string) string {
+	body = strings.Trim(body, "\n")
+	if body == "" {
+		return ""
+	}
+	return body + "\n"
+}
+
+// managedBounds returns the byte offsets of the body of the managed section
+// name: from the end of the start marker to the start of the end marker
+func managedBounds(content, name string) (start, end int, ok bool) {
+	startIdx := strings.Index(content, StartMarker(name))
+	if startIdx == -1 {
+		return 0, 0, false
+	}
+	start = startIdx + len(StartMarker(name))
+	endIdx := strings.Index(content[start:], EndMarker(name))
+	if endIdx == -1 {
+		return 0, 0, false
+	}
+	return start, start + endIdx, true
+}
@@ -0,0 +1,36 @@
+package marker
+
+import "testing"
+
+func TestManagedSections(t *testing.T) {
+	content := "# App\n\n<!-- readme-gen:badges:start -->\nold\n<!-- readme-gen:badges:end -->\n\n" +
+		Wrap("└── src/") + "\n\n<!-- readme-gen:tasks:start -->\nno end marker\n"
+
+	names := ManagedNames(content)
+	if len(names) != 2 || names[0] != "badges" || names[1] != StructureSection {
+		t.Errorf("ManagedNames() = %v, want [badges structure]", names)
+	}
+
+	if body, ok := ExtractManaged(content, "badges"); !ok || body != "old" {
+		t.Errorf("ExtractManaged() = %q, %v, want old", body, ok)
+	}
+	if _, ok := ExtractManaged(content, "tasks"); ok {
+		t.Error("ExtractManaged() should fail without an end marker")
+	}
+
+	updated, err := UpdateManaged(content, "badges", "new 1\nnew 2\n")
+	if err != nil {
+		t.Fatalf("UpdateManaged() error = %v", err)
+	}
+	if body, _ := ExtractManaged(updated, "badges"); body != "new 1\nnew 2" {
+		t.Errorf("body after update = %q", body)
+	}
+	if _, err := UpdateManaged(content, "env", "x"); err == nil {
+		t.Error("UpdateManaged() should fail without markers")
+	}
+
+	// Empty sections keep their markers on adjacent lines
+	if got := WrapManaged("badges", ""); got != "<!-- readme-gen:badges:start -->\n<!-- readme-gen:badges:end -->" {
+		t.Errorf("WrapManaged() = %q", got)
+	}
+}
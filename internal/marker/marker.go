@@ -17,34 +17,50 @@ var markerRegex = regexp.MustCompile(`(?s)` + regexp.QuoteMeta(MarkerStart) + `.
 
 // Extract extracts the structure content between markers
 func Extract(content string) (string, bool) {
+	structure, _, ok := ExtractWithLine(content)
+	return structure, ok
+}
+
+// ExtractWithLine extracts the structure content between markers along with
+// the 1-based line number in content where the structure starts
+func ExtractWithLine(content string) (string, int, bool) {
 	startIdx := strings.Index(content, MarkerStart)
 	endIdx := strings.Index(content, MarkerEnd)
 
 	if startIdx == -1 || endIdx == -1 || startIdx >= endIdx {
-		return "", false
+		return "", 0, false
 	}
 
 	// Extract content between markers
 	between := content[startIdx+len(MarkerStart) : endIdx]
+	baseLine := strings.Count(content[:startIdx], "\n") + 1
 
 	// Find the code block content
 	lines := strings.Split(between, "\n")
 	var structureLines []string
+	firstLine := 0
 	inCodeBlock := false
 
-	for _, line := range lines {
+	for i, line := range lines {
 		trimmed := strings.TrimSpace(line)
 		if strings.HasPrefix(trimmed, "```") {
 			inCodeBlock = !inCodeBlock
 			continue
 		}
 		if inCodeBlock {
+			// Leading blank lines are trimmed from the result
+			if len(structureLines) == 0 && trimmed == "" {
+				continue
+			}
+			if len(structureLines) == 0 {
+				firstLine = baseLine + i
+			}
 			structureLines = append(structureLines, line)
 		}
 	}
 
 	result := strings.Join(structureLines, "\n")
-	return strings.TrimSpace(result), true
+	return strings.TrimSpace(result), firstLine, true
 }
 
 // Update updates the structure section between markers
@@ -55,7 +71,8 @@ func Update(content string, structure string) (string, error) {
 
 	newSection := fmt.Sprintf("%s\n```\n%s\n```\n%s", MarkerStart, structure, MarkerEnd)
 
-	result := markerRegex.ReplaceAllString(content, newSection)
+	// Literal replacement: "$" in comments is not a group reference
+	result := markerRegex.ReplaceAllLiteralString(content, newSection)
 	return result, nil
 }
 
@@ -64,20 +81,18 @@ func Wrap(structure string) string {
 	return fmt.Sprintf("%s\n```\n%s\n```\n%s", MarkerStart, structure, MarkerEnd)
 }
 
-// StripComments removes inline comments (# ...) from structure lines for comparison
+// StripComments removes inline comments (# ...) from structure lines for comparison.
+// Comment-only continuation lines of wrapped comments are dropped.
 func StripComments(structure string) string {
 	lines := strings.Split(structure, "\n")
 	var result []string
 
 	for _, line := range lines {
-		// Find comment marker that's not inside the tree structure
-		// Comments typically appear after directory/file names with spaces
-		if idx := strings.Index(line, "  #"); idx != -1 {
-			line = strings.TrimRight(line[:idx], " ")
-		} else if idx := strings.Index(line, "\t#"); idx != -1 {
-			line = strings.TrimRight(line[:idx], " \t")
+		tree, comment := splitComment(line)
+		if isContinuation(tree, comment) {
+			continue
 		}
-		result = append(result, line)
+		result = append(result, strings.TrimRight(tree, " \t"))
 	}
 
 	return strings.Join(result, "\n")
@@ -115,6 +115,19 @@ old/
 	}
 }
 
+func TestUpdate_DollarSign(t *testing.T) {
+	content := "# README\n\n" + Wrap("old/") + "\n"
+	structure := "└── billing/  # Costs $5 per $1k calls"
+
+	result, err := Update(content, structure)
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if got, _ := Extract(result); got != structure {
+		t.Errorf("Extract() = %q, want %q", got, structure)
+	}
+}
+
 func TestUpdate_NoMarkers(t *testing.T) {
 	content := "# README\n\nNo markers here"
 
@@ -141,3 +154,18 @@ func TestWrap(t *testing.T) {
 		t.Error("expected result to contain structure")
 	}
 }
+
+func TestExtractWithLine(t *testing.T) {
+	content := "# README\n\n## Structure\n\n" + MarkerStart + "\n```\n\n├── cmd/\n└── internal/\n```\n" + MarkerEnd + "\n"
+
+	structure, line, ok := ExtractWithLine(content)
+	if !ok {
+		t.Fatal("expected markers to be found")
+	}
+	if structure != "├── cmd/\n└── internal/" {
+		t.Errorf("unexpected structure: %q", structure)
+	}
+	if line != 8 {
+		t.Errorf("expected structure to start on line 8, got %d", line)
+	}
+}
@@ -0,0 +1,219 @@
+package marker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Heading is a Markdown ATX heading (e.g. "## Structure")
+type Heading struct {
+	// Line is the 0-based line index in the content
+	Line int
+	// Level is the number of leading "#"
+	Level int
+	// Title is the heading text
+	Title string
+}
+
+// StructureHeadings are heading titles (case-insensitive) that introduce
+// a structure section in existing READMEs
+var StructureHeadings = []string{
+	"structure",
+	"project structure",
+	"directory structure",
+	"folder structure",
+	"構造",
+	"構成",
+	"ディレクトリ構成",
+	"ディレクトリ構造",
+}
+
+// Positions for InsertOptions.Position
+const (
+	// PositionEnd appends the new section at the end of the file
+	PositionEnd = "end"
+	// PositionTop inserts the new section before the first second-level heading
+	PositionTop = "top"
+	// PositionBefore is a prefix: "before:Usage" inserts before the Usage section
+	PositionBefore = "before:"
+	// PositionAfter is a prefix: "after:Installation" inserts after the Installation section
+	PositionAfter = "after:"
+)
+
+// InsertOptions configures Insert
+type InsertOptions struct {
+	// Position of a new section when no structure heading exists (default: end)
+	Position string
+	// Heading is the heading line of a new section (e.g. "## Structure")
+	Heading string
+}
+
+// Headings returns the ATX headings of content, ignoring fenced code blocks
+func Headings(content string) []Heading {
+	var (
+		headings []Heading
+		inFence  bool
+	)
+
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+		rest := trimmed[level:]
+		if level > 6 || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+			continue
+		}
+		title := strings.TrimSpace(strings.TrimRight(strings.TrimSpace(rest), "#"))
+		headings = append(headings, Heading{Line: i, Level: level, Title: title})
+	}
+
+	return headings
+}
+
+// FindHeading returns the first heading whose title matches one of titles
+// (case-insensitive). Titles may include leading "#"s to require a level.
+func FindHeading(content string, titles ...string) (Heading, bool) {
+	for _, h := range Headings(content) {
+		for _, t := range titles {
+			level, title := splitHeading(t)
+			if level > 0 && level != h.Level {
+				continue
+			}
+			if strings.EqualFold(h.Title, title) {
+				return h, true
+			}
+		}
+	}
+	return Heading{}, false
+}
+
+// SectionEnd returns the 0-based line index where the section starting at h
+// ends: the next heading of the same or higher level, or the line count
+func SectionEnd(content string, h Heading) int {
+	for _, next := range Headings(content) {
+		if next.Line > h.Line && next.Level <= h.Level {
+			return next.Line
+		}
+	}
+	return len(strings.Split(content, "\n"))
+}
+
+// Insert adds structure markers with the given structure to content.
+// When a structure heading exists, the markers are placed right below it,
+// replacing a fenced code block that directly follows the heading.
+// Otherwise a new section is inserted at opts.Position.
+// The rest of the content is left untouched.
+func Insert(content, structure string, opts InsertOptions) (string, error) {
+	return Target{}.Insert(content, structure, opts)
+}
+
+// insertBlock places block below the first heading matching titles, or in
+// a new section at opts.Position
+func insertBlock(content string, block []string, titles []string, opts InsertOptions) (string, error) {
+	lines := strings.Split(content, "\n")
+
+	if h, ok := FindHeading(content, titles...); ok {
+		start, end := h.Line+1, h.Line+1
+		// Skip blank lines after the heading
+		for start < len(lines) && strings.TrimSpace(lines[start]) == "" {
+			start++
+		}
+		// Replace a hand-written tree directly below the heading
+		if start < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[start]), "```") {
+			if closing := fenceEnd(lines, start); closing != -1 {
+				return joinLines(lines[:start], block, lines[closing+1:]), nil
+			}
+		}
+		section := append([]string{""}, block...)
+		if end < len(lines) && strings.TrimSpace(lines[end]) != "" {
+			section = append(section, "")
+		}
+		return joinLines(lines[:end], section, lines[end:]), nil
+	}
+
+	section := append([]string{opts.Heading, ""}, block...)
+	at, err := insertionLine(content, lines, opts.Position)
+	if err != nil {
+		return "", err
+	}
+
+	// Keep a blank line between the new section and its neighbors
+	before := lines[:at]
+	after := lines[at:]
+	for len(before) > 0 && strings.TrimSpace(before[len(before)-1]) == "" {
+		before = before[:len(before)-1]
+	}
+	if len(before) > 0 {
+		section = append([]string{""}, section...)
+	}
+	if len(after) > 0 && strings.Join(after, "") != "" {
+		section = append(section, "")
+	} else {
+		after = []string{""}
+	}
+	return joinLines(before, section, after), nil
+}
+
+// insertionLine resolves an insert position to a 0-based line index
+func insertionLine(content string, lines []string, position string) (int, error) {
+	switch {
+	case position == "" || position == PositionEnd:
+		return len(lines), nil
+	case position == PositionTop:
+		for _, h := range Headings(content) {
+			if h.Level >= 2 {
+				return h.Line, nil
+			}
+		}
+		return len(lines), nil
+	case strings.HasPrefix(position, PositionBefore):
+		title := strings.TrimPrefix(position, PositionBefore)
+		h, ok := FindHeading(content, title)
+		if !ok {
+			return 0, fmt.Errorf("heading %q not found", title)
+		}
+		return h.Line, nil
+	case strings.HasPrefix(position, PositionAfter):
+		title := strings.TrimPrefix(position, PositionAfter)
+		h, ok := FindHeading(content, title)
+		if !ok {
+			return 0, fmt.Errorf("heading %q not found", title)
+		}
+		return SectionEnd(content, h), nil
+	default:
+		return 0, fmt.Errorf("unknown position %q (expected %s, %s, %s<heading> or %s<heading>)", position, PositionEnd, PositionTop, PositionBefore, PositionAfter)
+	}
+}
+
+// splitHeading splits "## Title" into its level and title (level 0 if no "#")
+func splitHeading(s string) (int, string) {
+	s = strings.TrimSpace(s)
+	level := len(s) - len(strings.TrimLeft(s, "#"))
+	return level, strings.TrimSpace(s[level:])
+}
+
+// fenceEnd returns the index of the line closing the fence opened at start, or -1
+func fenceEnd(lines []string, start int) int {
+	for i := start + 1; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "```") {
+			return i
+		}
+	}
+	return -1
+}
+
+// joinLines concatenates line slices into content
+func joinLines(parts ...[]string) string {
+	var all []string
+	for _, p := range parts {
+		all = append(all, p...)
+	}
+	return strings.Join(all, "\n")
+}
@@ -0,0 +1,99 @@
+package marker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHeadings(t *testing.T) {
+	content := "# Title\n\n## Usage ##\n\n```bash\n# not a heading\n```\n\n### ディレクトリ構成\n#hashtag"
+
+	headings := Headings(content)
+
+	want := []Heading{
+		{Line: 0, Level: 1, Title: "Title"},
+		{Line: 2, Level: 2, Title: "Usage"},
+		{Line: 8, Level: 3, Title: "ディレクトリ構成"},
+	}
+	if len(headings) != len(want) {
+		t.Fatalf("Headings() returned %d headings, want %d: %+v", len(headings), len(want), headings)
+	}
+	for i := range want {
+		if headings[i] != want[i] {
+			t.Errorf("heading %d = %+v, want %+v", i, headings[i], want[i])
+		}
+	}
+}
+
+func TestInsert_ExistingHeading(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "replaces hand-written tree",
+			content: "# App\n\n## Project Structure\n\n```\nold/\n```\n\n## Usage\n",
+			want:    "# App\n\n## Project Structure\n\n" + Wrap("└── cmd/") + "\n\n## Usage\n",
+		},
+		{
+			name:    "inserts below heading",
+			content: "# App\n\n## ディレクトリ構成\nSee below.\n",
+			want:    "# App\n\n## ディレクトリ構成\n\n" + Wrap("└── cmd/") + "\n\nSee below.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Insert(tt.content, "└── cmd/", InsertOptions{Heading: "## Structure"})
+			if err != nil {
+				t.Fatalf("Insert failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Insert() =\n%q\nwant:\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsert_NewSection(t *testing.T) {
+	content := "# App\n\nIntro.\n\n## Installation\n\nSteps.\n\n## Usage\n\nRun it.\n"
+	section := "## Structure\n\n" + Wrap("└── cmd/")
+
+	tests := []struct {
+		position string
+		want     string
+	}{
+		{PositionEnd, content + "\n" + section + "\n"},
+		{PositionTop, "# App\n\nIntro.\n\n" + section + "\n\n## Installation\n\nSteps.\n\n## Usage\n\nRun it.\n"},
+		{"before:Usage", "# App\n\nIntro.\n\n## Installation\n\nSteps.\n\n" + section + "\n\n## Usage\n\nRun it.\n"},
+		{"after:## installation", "# App\n\nIntro.\n\n## Installation\n\nSteps.\n\n" + section + "\n\n## Usage\n\nRun it.\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.position, func(t *testing.T) {
+			got, err := Insert(content, "└── cmd/", InsertOptions{Position: tt.position, Heading: "## Structure"})
+			if err != nil {
+				t.Fatalf("Insert failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Insert() =\n%q\nwant:\n%q", got, tt.want)
+			}
+			if _, ok := Extract(got); !ok {
+				t.Error("expected markers to be extractable")
+			}
+		})
+	}
+}
+
+func TestInsert_Errors(t *testing.T) {
+	if _, err := Insert(Wrap("x"), "y", InsertOptions{}); err == nil {
+		t.Error("expected error when markers already exist")
+	}
+	if _, err := Insert("# App\n", "y", InsertOptions{Position: "before:Missing"}); err == nil || !strings.Contains(err.Error(), "Missing") {
+		t.Errorf("expected heading not found error, got %v", err)
+	}
+	if _, err := Insert("# App\n", "y", InsertOptions{Position: "middle"}); err == nil {
+		t.Error("expected error for unknown position")
+	}
+}
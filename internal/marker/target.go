@@ -0,0 +1,107 @@
+package marker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Target identifies the structure block of a README: the fenced block
+// between markers (default), or the first fenced block in the section
+// under a heading for READMEs that cannot contain HTML comments.
+type Target struct {
+	// Heading selects heading mode (e.g. "## Structure"); empty uses markers
+	Heading string
+}
+
+// Extract extracts the structure content of the target block
+func (t Target) Extract(content string) (string, bool) {
+	structure, _, ok := t.ExtractWithLine(content)
+	return structure, ok
+}
+
+// ExtractWithLine extracts the structure content of the target block along
+// with the 1-based line number in content where the structure starts
+func (t Target) ExtractWithLine(content string) (string, int, bool) {
+	if t.Heading == "" {
+		return ExtractWithLine(content)
+	}
+
+	lines := strings.Split(content, "\n")
+	open, closing, ok := t.locate(content, lines)
+	if !ok {
+		return "", 0, false
+	}
+
+	inner := lines[open+1 : closing]
+	first := 0
+	for first < len(inner) && strings.TrimSpace(inner[first]) == "" {
+		first++
+	}
+
+	result := strings.TrimSpace(strings.Join(inner[first:], "\n"))
+	return result, open + 1 + first + 1, true
+}
+
+// Update replaces the structure content of the target block
+func (t Target) Update(content string, structure string) (string, error) {
+	if t.Heading == "" {
+		return Update(content, structure)
+	}
+
+	lines := strings.Split(content, "\n")
+	open, closing, ok := t.locate(content, lines)
+	if !ok {
+		return "", fmt.Errorf("no code block found under heading %q", t.Heading)
+	}
+
+	return joinLines(lines[:open+1], strings.Split(structure, "\n"), lines[closing:]), nil
+}
+
+// Insert adds the target block to content. In marker mode this is the
+// package-level Insert behavior. In heading mode a plain fenced block is
+// placed under the heading, which is created at opts.Position if missing.
+func (t Target) Insert(content, structure string, opts InsertOptions) (string, error) {
+	if t.Heading == "" {
+		if strings.Contains(content, MarkerStart) || strings.Contains(content, MarkerEnd) {
+			return "", fmt.Errorf("markers already exist")
+		}
+		block := strings.Split(Wrap(structure), "\n")
+		return insertBlock(content, block, StructureHeadings, opts)
+	}
+
+	if _, ok := t.Extract(content); ok {
+		return "", fmt.Errorf("structure block already exists under heading %q", t.Heading)
+	}
+
+	level, title := splitHeading(t.Heading)
+	if level == 0 {
+		level = 2
+	}
+	opts.Heading = strings.Repeat("#", level) + " " + title
+
+	block := []string{"```", structure, "```"}
+	return insertBlock(content, block, []string{t.Heading}, opts)
+}
+
+// locate returns the line indexes of the opening and closing fence of the
+// first code block in the section under the target heading
+func (t Target) locate(content string, lines []string) (open, closing int, ok bool) {
+	h, found := FindHeading(content, t.Heading)
+	if !found {
+		return 0, 0, false
+	}
+
+	end := SectionEnd(content, h)
+	for i := h.Line + 1; i < end; i++ {
+		if !strings.HasPrefix(strings.TrimSpace(lines[i]), "```") {
+			continue
+		}
+		closing := fenceEnd(lines, i)
+		if closing == -1 || closing >= end {
+			return 0, 0, false
+		}
+		return i, closing, true
+	}
+
+	return 0, 0, false
+}
@@ -0,0 +1,85 @@
+package marker
+
+import (
+	"strings"
+	"testing"
+)
+
+const headingReadme = `# App
+
+## Installation
+
+` + "```bash" + `
+go install example.com/app@latest
+` + "```" + `
+
+## Structure
+
+Layout of the repository:
+
+` + "```" + `
+├── cmd/     # CLI entry point
+└── old/
+` + "```" + `
+
+## License
+`
+
+func TestTarget_HeadingExtract(t *testing.T) {
+	target := Target{Heading: "## Structure"}
+
+	structure, line, ok := target.ExtractWithLine(headingReadme)
+	if !ok {
+		t.Fatal("expected structure block to be found")
+	}
+	if structure != "├── cmd/     # CLI entry point\n└── old/" {
+		t.Errorf("unexpected structure: %q", structure)
+	}
+	if line != 14 {
+		t.Errorf("expected structure to start on line 14, got %d", line)
+	}
+
+	if _, ok := (Target{Heading: "## License"}).Extract(headingReadme); ok {
+		t.Error("expected no block under License")
+	}
+	if _, ok := (Target{Heading: "Missing"}).Extract(headingReadme); ok {
+		t.Error("expected missing heading not to be found")
+	}
+}
+
+func TestTarget_HeadingUpdate(t *testing.T) {
+	target := Target{Heading: "structure"}
+
+	result, err := target.Update(headingReadme, "└── cmd/")
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	want := strings.Replace(headingReadme, "├── cmd/     # CLI entry point\n└── old/", "└── cmd/", 1)
+	if result != want {
+		t.Errorf("Update() =\n%s\nwant:\n%s", result, want)
+	}
+	if strings.Contains(result, "<!--") {
+		t.Error("expected no markers in heading mode")
+	}
+
+	if _, err := (Target{Heading: "## License"}).Update(headingReadme, "x"); err == nil {
+		t.Error("expected error when no code block under heading")
+	}
+}
+
+func TestTarget_HeadingInsert(t *testing.T) {
+	target := Target{Heading: "Layout"}
+
+	result, err := target.Insert("# App\n\nIntro.\n", "└── cmd/", InsertOptions{})
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if result != "# App\n\nIntro.\n\n## Layout\n\n```\n└── cmd/\n```\n" {
+		t.Errorf("unexpected result: %q", result)
+	}
+
+	if _, err := target.Insert(result, "└── cmd/", InsertOptions{}); err == nil {
+		t.Error("expected error when block already exists")
+	}
+}
@@ -0,0 +1,261 @@
+package marker
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Entry is a single directory or file line in a structure tree
+type Entry struct {
+	// Line is the 0-based line index within the structure
+	Line int
+	// Path is the slash-separated path relative to the tree root, without trailing slash
+	Path string
+	// IsDir reports whether the entry is rendered with a trailing slash
+	IsDir bool
+	// Tree is the line without its comment (e.g. "│   ├── api/")
+	Tree string
+	// Comment is the inline comment text without the leading "#".
+	// Wrapped comments are joined with single spaces.
+	Comment string
+}
+
+// connectors are the tree drawing prefixes that precede an entry name
+var connectors = []string{"├── ", "└── "}
+
+// ParseTree parses a structure tree into entries. Elision lines such as
+// "└── … (3 more)" and blank lines are skipped. Lines without a connector
+// (e.g. "src/") are treated as roots for the connector lines that follow.
+// Comment-only lines continue the comment of the entry above them.
+func ParseTree(structure string) []Entry {
+	var (
+		entries []Entry
+		root    string
+		stack   []string
+	)
+
+	for i, line := range strings.Split(structure, "\n") {
+		tree, comment := splitComment(line)
+
+		if isContinuation(tree, comment) {
+			if n := len(entries); n > 0 {
+				entries[n-1].Comment = joinComment(entries[n-1].Comment, comment)
+			}
+			continue
+		}
+		if strings.Trim(tree, "│ \t") == "" {
+			continue
+		}
+
+		level, name, ok := splitConnector(tree)
+		if !ok {
+			// Root line without connector
+			name = strings.TrimSpace(tree)
+			root = strings.TrimSuffix(name, "/")
+			stack = stack[:0]
+			entries = append(entries, Entry{
+				Line:    i,
+				Path:    root,
+				IsDir:   strings.HasSuffix(name, "/"),
+				Tree:    tree,
+				Comment: comment,
+			})
+			continue
+		}
+
+		if strings.HasPrefix(name, "…") {
+			continue
+		}
+
+		isDir := strings.HasSuffix(name, "/")
+		name = strings.TrimSuffix(name, "/")
+
+		if level > len(stack) {
+			level = len(stack)
+		}
+		stack = append(stack[:level], name)
+
+		path := strings.Join(stack, "/")
+		if root != "" {
+			path = root + "/" + path
+		}
+
+		entries = append(entries, Entry{
+			Line:    i,
+			Path:    path,
+			IsDir:   isDir,
+			Tree:    tree,
+			Comment: comment,
+		})
+	}
+
+	return entries
+}
+
+// Annotate sets the comments of a structure tree, keyed by entry path,
+// and formats the result like Format. Existing comments are replaced.
+func Annotate(structure string, comments map[string]string, width int) string {
+	lines := parseLines(structure)
+
+	byLine := make(map[int]string)
+	for _, e := range ParseTree(structure) {
+		byLine[e.Line] = comments[e.Path]
+	}
+	for i := range lines {
+		lines[i].comment = byLine[lines[i].index]
+	}
+
+	return renderLines(lines, width)
+}
+
+// Format normalizes the comments of a structure tree: every comment is
+// separated by "# ", aligned to a common column two spaces after the
+// longest line, and wrapped onto continuation lines when a line would
+// exceed width (0 = no wrapping). Format is idempotent.
+func Format(structure string, width int) string {
+	return renderLines(parseLines(structure), width)
+}
+
+// treeLine is a structure line with its (possibly wrapped) comment merged
+type treeLine struct {
+	index   int
+	tree    string
+	comment string
+}
+
+// parseLines splits a structure into lines, merging comment-only
+// continuation lines into the line above
+func parseLines(structure string) []treeLine {
+	var lines []treeLine
+	for i, line := range strings.Split(structure, "\n") {
+		tree, comment := splitComment(line)
+		if isContinuation(tree, comment) && len(lines) > 0 {
+			last := &lines[len(lines)-1]
+			last.comment = joinComment(last.comment, comment)
+			continue
+		}
+		lines = append(lines, treeLine{
+			index:   i,
+			tree:    strings.TrimRight(tree, " \t"),
+			comment: joinComment("", comment),
+		})
+	}
+	return lines
+}
+
+// renderLines writes lines with aligned comments, wrapping at width
+func renderLines(lines []treeLine, width int) string {
+	column := 0
+	for _, l := range lines {
+		if w := utf8.RuneCountInString(l.tree); w > column {
+			column = w
+		}
+	}
+	column += 2
+
+	var result []string
+	for _, l := range lines {
+		if l.comment == "" {
+			result = append(result, l.tree)
+			continue
+		}
+
+		chunks := wrapWords(l.comment, width-column-2)
+		pad := strings.Repeat(" ", column-utf8.RuneCountInString(l.tree))
+		result = append(result, l.tree+pad+"# "+chunks[0])
+
+		indent := continuationPrefix(l.tree)
+		pad = strings.Repeat(" ", column-utf8.RuneCountInString(indent))
+		for _, chunk := range chunks[1:] {
+			result = append(result, indent+pad+"# "+chunk)
+		}
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// wrapWords splits text into chunks of at most limit runes on word
+// boundaries. Words longer than limit get a chunk of their own.
+func wrapWords(text string, limit int) []string {
+	if limit <= 0 || utf8.RuneCountInString(text) <= limit {
+		return []string{text}
+	}
+
+	var (
+		chunks  []string
+		current string
+	)
+	for _, word := range strings.Fields(text) {
+		if current == "" {
+			current = word
+			continue
+		}
+		if utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) > limit {
+			chunks = append(chunks, current)
+			current = word
+			continue
+		}
+		current += " " + word
+	}
+	return append(chunks, current)
+}
+
+// continuationPrefix returns the tree prefix for comment lines that
+// continue the comment of tree, keeping vertical lines intact
+func continuationPrefix(tree string) string {
+	for _, c := range connectors {
+		idx := strings.Index(tree, c)
+		if idx == -1 {
+			continue
+		}
+		if c == "├── " {
+			return tree[:idx] + "│   "
+		}
+		return tree[:idx] + "    "
+	}
+	return ""
+}
+
+// isContinuation reports whether a line only carries a comment
+func isContinuation(tree, comment string) bool {
+	return comment != "" && strings.Trim(tree, "│ \t") == ""
+}
+
+// joinComment appends a wrapped comment chunk, normalizing whitespace
+func joinComment(comment, chunk string) string {
+	return strings.Join(strings.Fields(comment+" "+chunk), " ")
+}
+
+// splitConnector returns the nesting level and name of a tree line
+func splitConnector(tree string) (level int, name string, ok bool) {
+	for _, c := range connectors {
+		idx := strings.Index(tree, c)
+		if idx == -1 {
+			continue
+		}
+		prefix := tree[:idx]
+		if strings.Trim(prefix, "│ \t") != "" {
+			continue
+		}
+		level = utf8.RuneCountInString(prefix) / 4
+		name = strings.TrimSpace(tree[idx+len(c):])
+		return level, name, name != ""
+	}
+	return 0, "", false
+}
+
+// splitComment splits a tree line into the tree part and comment text.
+// A comment starts at a "#" preceded by whitespace, or at the start of a
+// line that only carries a comment.
+func splitComment(line string) (tree, comment string) {
+	for i := 0; i < len(line); i++ {
+		if line[i] != '#' {
+			continue
+		}
+		if i > 0 && line[i-1] != ' ' && line[i-1] != '\t' {
+			continue
+		}
+		return strings.TrimRight(line[:i], " \t"), strings.TrimSpace(line[i+1:])
+	}
+	return line, ""
+}
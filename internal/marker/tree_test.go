@@ -0,0 +1,116 @@
+package marker
+
+import (
+	"testing"
+)
+
+func TestParseTree(t *testing.T) {
+	structure := `├── cmd/               # CLI entry point
+│   └── readme-gen/
+├── internal/
+│   ├── scanner/ # Directory scanning
+│   ├── … (3 more)
+│   └── ui/	# Terminal UI
+└── go.mod`
+
+	entries := ParseTree(structure)
+
+	want := []Entry{
+		{Line: 0, Path: "cmd", IsDir: true, Tree: "├── cmd/", Comment: "CLI entry point"},
+		{Line: 1, Path: "cmd/readme-gen", IsDir: true, Tree: "│   └── readme-gen/"},
+		{Line: 2, Path: "internal", IsDir: true, Tree: "├── internal/"},
+		{Line: 3, Path: "internal/scanner", IsDir: true, Tree: "│   ├── scanner/", Comment: "Directory scanning"},
+		{Line: 5, Path: "internal/ui", IsDir: true, Tree: "│   └── ui/", Comment: "Terminal UI"},
+		{Line: 6, Path: "go.mod", IsDir: false, Tree: "└── go.mod"},
+	}
+
+	if len(entries) != len(want) {
+		t.Fatalf("ParseTree() returned %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, entries[i], want[i])
+		}
+	}
+}
+
+func TestParseTree_RootLine(t *testing.T) {
+	structure := `src/
+├── api/
+└── models/`
+
+	entries := ParseTree(structure)
+
+	paths := []string{"src", "src/api", "src/models"}
+	if len(entries) != len(paths) {
+		t.Fatalf("ParseTree() returned %d entries, want %d", len(entries), len(paths))
+	}
+	for i, p := range paths {
+		if entries[i].Path != p {
+			t.Errorf("entry %d path = %q, want %q", i, entries[i].Path, p)
+		}
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	structure := `├── cmd/  # old comment
+│   └── readme-gen/
+└── internal/`
+
+	result := Annotate(structure, map[string]string{
+		"cmd":      "CLI entry point",
+		"internal": "Internal packages",
+	}, 0)
+
+	want := `├── cmd/             # CLI entry point
+│   └── readme-gen/
+└── internal/        # Internal packages`
+	if result != want {
+		t.Errorf("Annotate() =\n%s\nwant:\n%s", result, want)
+	}
+
+	if StripComments(result) != StripComments(structure) {
+		t.Error("expected annotated tree to match original after stripping comments")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	structure := "├── cmd/ # CLI entry point\n" +
+		"│   └── readme-gen/\n" +
+		"├── internal/\t#Internal   packages\n" +
+		"│   └── ui/                          # Terminal UI styles and rendering helpers\n" +
+		"└── web/"
+
+	want := `├── cmd/             # CLI entry point
+│   └── readme-gen/
+├── internal/        # Internal packages
+│   └── ui/          # Terminal UI styles
+│                    # and rendering helpers
+└── web/`
+
+	got := Format(structure, 44)
+	if got != want {
+		t.Errorf("Format() =\n%s\nwant:\n%s", got, want)
+	}
+
+	if again := Format(got, 44); again != got {
+		t.Errorf("Format() is not idempotent:\n%s", again)
+	}
+
+	if StripComments(got) != StripComments(structure) {
+		t.Errorf("expected formatted tree to strip to the original tree, got:\n%s", StripComments(got))
+	}
+
+	entries := ParseTree(got)
+	if entries[3].Comment != "Terminal UI styles and rendering helpers" {
+		t.Errorf("expected wrapped comment to be joined, got %q", entries[3].Comment)
+	}
+}
+
+func TestFormat_NoWrap(t *testing.T) {
+	structure := "└── internal/  # A long comment that would otherwise be wrapped"
+
+	if got := Format(structure, 0); got != structure {
+		t.Errorf("Format() = %q, want %q", got, structure)
+	}
+}
@@ -0,0 +1,121 @@
+package scanner
+
+import (
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Binary is a Go main package that `go install` builds into a command
+type Binary struct {
+	// Name is the name of the installed command
+	Name string
+	// Path is the package path relative to the project root, as passed to
+	// go run or go build ("./cmd/tool", or "." for a main package at the root)
+	Path string
+	// ImportPath is the package path passed to go install
+	ImportPath string
+}
+
+// skipBinaryDirs are never searched for main packages: examples are not
+// meant to be installed
+var skipBinaryDirs = map[string]bool{
+	"example":  true,
+	"examples": true,
+}
+
+// majorVersion matches the major version suffix of a module path (v2, v3, ...)
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// DetectBinaries returns the main packages of the Go module at root, whose
+// module path is modulePath, sorted by path. Hidden, ignored and nested
+// module directories are skipped.
+func DetectBinaries(root, modulePath string) []Binary {
+	matcher := DefaultMatcher(root)
+
+	var binaries []Binary
+	var walk func(dir, relPath string)
+	walk = func(dir, relPath string) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		isMain := false
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() {
+				childRel := joinRelPath(relPath, name)
+				if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || skipPackageDirs[name] || skipBinaryDirs[name] || matcher.IsExcluded(childRel, true) {
+					continue
+				}
+				// Directories with their own go.mod belong to another module
+				if _, err := os.Stat(filepath.Join(dir, name, "go.mod")); err == nil {
+					continue
+				}
+				walk(filepath.Join(dir, name), childRel)
+				continue
+			}
+			if !isMain && strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+				isMain = isMainFile(filepath.Join(dir, name))
+			}
+		}
+		if isMain {
+			binaries = append(binaries, newBinary(modulePath, relPath))
+		}
+	}
+	walk(root, "")
+
+	sort.Slice(binaries, func(i, j int) bool { return binaries[i].Path < binaries[j].Path })
+	return binaries
+}
+
+// newBinary returns the binary built from the main package at relPath
+func newBinary(modulePath, relPath string) Binary {
+	b := Binary{Path: ".", ImportPath: modulePath}
+	if relPath != "" {
+		b.Path = "./" + relPath
+		b.ImportPath = modulePath + "/" + relPath
+	}
+
+	// go install names the command after the last path element that is not
+	// a major version suffix (example.com/tool/v2 installs "tool")
+	b.Name = path.Base(b.ImportPath)
+	if dir := path.Dir(b.ImportPath); majorVersion.MatchString(b.Name) && dir != "." {
+		b.Name = path.Base(dir)
+	}
+	return b
+}
+
+// isMainFile reports whether the Go file at path declares package main and
+// is not excluded from every build (//go:build ignore)
+func isMainFile(path string) bool {
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.PackageClauseOnly|parser.ParseComments)
+	if err != nil || f.Name.Name != "main" {
+		return false
+	}
+	for _, group := range f.Comments {
+		if group.Pos() > f.Package {
+			break
+		}
+		for _, c := range group.List {
+			expr, err := constraint.Parse(c.Text)
+			if err != nil {
+				continue
+			}
+			// Constraints that fail whether or not the platform tags are set,
+			// such as "ignore", exclude the file from every build
+			withTags := expr.Eval(func(tag string) bool { return tag != "ignore" })
+			withoutTags := expr.Eval(func(tag string) bool { return false })
+			if !withTags && !withoutTags {
+				return false
+			}
+		}
+	}
+	return true
+}
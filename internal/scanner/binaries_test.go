@@ -0,0 +1,58 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectBinaries(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod":                   "module example.com/tool/v2\n",
+		"main.go":                  "// Command tool does things\npackage main\n",
+		"lib.go":                   "package main\n",
+		"cmd/server/main.go":       "package main\n\nfunc main() {}\n",
+		"cmd/server/main_test.go":  "package main\n",
+		"cmd/gen/gen.go":           "//go:build ignore\n\npackage main\n",
+		"cmd/linux/main.go":        "//go:build linux\n\npackage main\n",
+		"internal/util/util.go":    "package util\n",
+		"examples/basic/main.go":   "package main\n",
+		"tools/go.mod":             "module example.com/tool/tools\n",
+		"tools/lint/main.go":       "package main\n",
+		"node_modules/x/main.go":   "package main\n",
+		".github/scripts/main.go":  "package main\n",
+		"internal/tests/x_test.go": "package main\n",
+	})
+
+	want := []Binary{
+		{Name: "tool", Path: ".", ImportPath: "example.com/tool/v2"},
+		{Name: "linux", Path: "./cmd/linux", ImportPath: "example.com/tool/v2/cmd/linux"},
+		{Name: "server", Path: "./cmd/server", ImportPath: "example.com/tool/v2/cmd/server"},
+	}
+	if got := DetectBinaries(root, "example.com/tool/v2"); !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectBinaries() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestDetectProjectInfo_Binaries(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod":                 "module github.com/owner/readme-gen\n",
+		"cmd/readme-gen/main.go": "package main\n",
+		"internal/cmd/root.go":   "package cmd\n",
+		"web/package.json":       `{"name": "web"}`,
+	})
+
+	info := DetectProjectInfo(root)
+	want := []Binary{{Name: "readme-gen", Path: "./cmd/readme-gen", ImportPath: "github.com/owner/readme-gen/cmd/readme-gen"}}
+	if !reflect.DeepEqual(info.Binaries, want) {
+		t.Errorf("Binaries = %+v, want %+v", info.Binaries, want)
+	}
+
+	// Projects without a go.mod have no binaries
+	root = t.TempDir()
+	writeFiles(t, root, map[string]string{"main.go": "package main\n"})
+	if info := DetectProjectInfo(root); info.Binaries != nil {
+		t.Errorf("expected no binaries without go.mod, got %+v", info.Binaries)
+	}
+}
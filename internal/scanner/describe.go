@@ -0,0 +1,200 @@
+package scanner
+
+import (
+	"bufio"
+	"encoding/json"
+	"go/doc"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hulk510/readme-gen/internal/config"
+)
+
+// DescriptionFileName is a plain-text file holding a directory's description
+const DescriptionFileName = ".readme-gen-description"
+
+// describers maps description sources to their implementations
+var describers = map[string]func(dir string) string{
+	config.SourceFile:        describeFromFile,
+	config.SourceGoDoc:       describeFromGoDoc,
+	config.SourceReadme:      describeFromReadme,
+	config.SourcePackageJSON: describeFromPackageJSON,
+}
+
+// Describe derives a one-line description for the directory at dir,
+// trying each source in order and returning the first non-empty result
+func Describe(dir string, sources []string) string {
+	for _, source := range sources {
+		describe, ok := describers[source]
+		if !ok {
+			continue
+		}
+		if desc := describe(dir); desc != "" {
+			return desc
+		}
+	}
+	return ""
+}
+
+// DescribePaths derives descriptions for the given relative directory paths
+// under root. Paths without a description are omitted from the result.
+func DescribePaths(root string, paths []string, sources []string) map[string]string {
+	result := make(map[string]string)
+	if len(sources) == 0 {
+		return result
+	}
+	for _, p := range paths {
+		if desc := Describe(filepath.Join(root, filepath.FromSlash(p)), sources); desc != "" {
+			result[p] = desc
+		}
+	}
+	return result
+}
+
+// describeFromFile returns the first sentence of the first line of a
+// .readme-gen-description file
+func describeFromFile(dir string) string {
+	content, err := os.ReadFile(filepath.Join(dir, DescriptionFileName))
+	if err != nil {
+		return ""
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(string(content)), "\n")
+	return summarize(line)
+}
+
+// describeFromGoDoc returns the first sentence of the package comment,
+// preferring doc.go over other non-test Go files
+func describeFromGoDoc(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		files = append(files, name)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i] == "doc.go" && files[j] != "doc.go"
+	})
+
+	for _, name := range files {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.PackageClauseOnly|parser.ParseComments)
+		if err != nil || f.Doc == nil {
+			continue
+		}
+		synopsis := new(doc.Package).Synopsis(f.Doc.Text())
+		if synopsis == "" {
+			continue
+		}
+		// "Package scanner walks directories." -> "Walks directories"
+		return tidySentence(strings.TrimPrefix(synopsis, "Package "+f.Name.Name+" "))
+	}
+
+	return ""
+}
+
+// describeFromReadme returns the first prose paragraph of a nested README,
+// keeping all of its sentences
+func describeFromReadme(dir string) string {
+	for _, name := range []string{"README.md", "readme.md", "Readme.md", "README"} {
+		file, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		paragraph := firstParagraph(file)
+		file.Close()
+		return tidySentence(paragraph)
+	}
+	return ""
+}
+
+// firstParagraph scans markdown and returns the first paragraph of prose,
+// skipping headings, badges, HTML, lists, quotes and code blocks
+func firstParagraph(file *os.File) string {
+	var (
+		lines   []string
+		inFence bool
+	)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		if line == "" {
+			if len(lines) > 0 {
+				break
+			}
+			continue
+		}
+
+		if len(lines) == 0 && isNonProse(line) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, " ")
+}
+
+// isNonProse reports whether a markdown line cannot start a prose paragraph
+func isNonProse(line string) bool {
+	for _, prefix := range []string{"#", "<", "![", "[![", ">", "- ", "* ", "|", "---", "==="} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// describeFromPackageJSON returns the first sentence of the description
+// field of package.json
+func describeFromPackageJSON(dir string) string {
+	content, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return ""
+	}
+	var pkg struct {
+		Description string `json:"description"`
+	}
+	if json.Unmarshal(content, &pkg) != nil {
+		return ""
+	}
+	return summarize(pkg.Description)
+}
+
+// summarize reduces a one-line description to its first sentence the way
+// go doc builds a package synopsis, then tidies it
+// ("Docs site. Built with Astro." -> "Docs site")
+func summarize(text string) string {
+	return tidySentence(new(doc.Package).Synopsis(text))
+}
+
+// tidySentence capitalizes the first letter and drops a trailing period
+func tidySentence(s string) string {
+	s = strings.TrimSuffix(strings.TrimSpace(s), ".")
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,71 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hulk510/readme-gen/internal/config"
+)
+
+func TestDescribe_Sources(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFiles(t, tmpDir, map[string]string{
+		"scanner/doc.go": `// Package scanner walks project directories. It also detects metadata.
+package scanner
+`,
+		"scanner/scanner.go": "// Package scanner is ignored in favor of doc.go.\npackage scanner\n",
+		"web/README.md": `# Web
+
+[![CI](https://example.com/badge.svg)](https://example.com)
+
+The web frontend
+built with React. It talks to the API.
+
+## Usage
+`,
+		"web/package.json":             `{"description": "From package.json. Extra details."}`,
+		"tools/" + DescriptionFileName: "developer tooling.\nsecond line\n",
+		"tools/doc.go":                 "// Package tools is described elsewhere.\npackage tools\n",
+	})
+
+	all := []string{config.SourceFile, config.SourceGoDoc, config.SourceReadme, config.SourcePackageJSON}
+
+	tests := []struct {
+		dir     string
+		sources []string
+		want    string
+	}{
+		{"scanner", all, "Walks project directories"},
+		{"web", all, "The web frontend built with React. It talks to the API"},
+		{"web", []string{config.SourcePackageJSON, config.SourceReadme}, "From package.json"},
+		{"tools", all, "Developer tooling"},
+		{"tools", []string{config.SourceGoDoc}, "Is described elsewhere"},
+		{"scanner", []string{config.SourceReadme}, ""},
+	}
+
+	for _, tt := range tests {
+		got := Describe(filepath.Join(tmpDir, tt.dir), tt.sources)
+		if got != tt.want {
+			t.Errorf("Describe(%s, %v) = %q, want %q", tt.dir, tt.sources, got, tt.want)
+		}
+	}
+}
+
+func TestDescribePaths(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFiles(t, tmpDir, map[string]string{
+		"internal/ui/doc.go":   "// Package ui renders terminal output.\npackage ui\n",
+		"internal/cmd/root.go": "package cmd\n",
+	})
+
+	result := DescribePaths(tmpDir, []string{"internal", "internal/ui", "internal/cmd"}, []string{config.SourceGoDoc})
+
+	if len(result) != 1 {
+		t.Fatalf("expected 1 description, got %v", result)
+	}
+	if result["internal/ui"] != "Renders terminal output" {
+		t.Errorf("unexpected description: %q", result["internal/ui"])
+	}
+}
@@ -0,0 +1,194 @@
+package scanner
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EnvVar is an environment variable the project reads
+type EnvVar struct {
+	// Name is the name of the variable (e.g. "DATABASE_URL")
+	Name string
+	// Default is the example value from the env file, if any
+	Default string
+	// Description is the comment above the variable or after its value
+	Description string
+}
+
+// envAssignment matches a variable in an env file (KEY=value or export KEY=value)
+var envAssignment = regexp.MustCompile(`^(?:export\s+)?([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*)$`)
+
+// DetectEnvVars returns the variables declared in the first of files found
+// in root, in order. With scanGo, variables read by the Go sources that the
+// files do not declare are added after them, sorted by name.
+func DetectEnvVars(root string, files []string, scanGo bool) []EnvVar {
+	var vars []EnvVar
+	if _, content, ok := readFirst(root, files...); ok {
+		vars = parseEnvFile(content)
+	}
+	if !scanGo {
+		return vars
+	}
+
+	declared := make(map[string]bool, len(vars))
+	for _, v := range vars {
+		declared[v.Name] = true
+	}
+	for _, name := range goEnvNames(root) {
+		if !declared[name] {
+			vars = append(vars, EnvVar{Name: name})
+		}
+	}
+	return vars
+}
+
+// parseEnvFile reads the variables of an env file. The comment lines directly
+// above a variable, or else the comment after an unquoted value, describe it.
+func parseEnvFile(content string) []EnvVar {
+	var vars []EnvVar
+	seen := make(map[string]bool)
+	var comment []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			comment = nil
+			continue
+		}
+		if text, ok := strings.CutPrefix(line, "#"); ok {
+			text = strings.TrimSpace(text)
+			// Commented-out variables are not descriptions
+			if envAssignment.MatchString(text) {
+				comment = nil
+			} else if text != "" {
+				comment = append(comment, text)
+			}
+			continue
+		}
+
+		m := envAssignment.FindStringSubmatch(line)
+		if m == nil {
+			comment = nil
+			continue
+		}
+		value, inline := envValue(m[2])
+		description := strings.Join(comment, " ")
+		if description == "" {
+			description = inline
+		}
+		comment = nil
+		if seen[m[1]] {
+			continue
+		}
+		seen[m[1]] = true
+		vars = append(vars, EnvVar{Name: m[1], Default: value, Description: description})
+	}
+	return vars
+}
+
+// envValue returns the value of an env file assignment without quotes and
+// the comment following an unquoted value
+func envValue(raw string) (value, comment string) {
+	if raw == "" {
+		return "", ""
+	}
+	if quote := raw[0]; quote == '"' || quote == '\'' {
+		if end := strings.IndexByte(raw[1:], quote); end >= 0 {
+			return raw[1 : end+1], ""
+		}
+		return raw[1:], ""
+	}
+	if i := strings.Index(raw, " #"); i >= 0 {
+		return strings.TrimSpace(raw[:i]), strings.TrimSpace(raw[i+2:])
+	}
+	return raw, ""
+}
+
+// goEnvNames returns the names passed as string literals to os.Getenv and
+// os.LookupEnv in the Go files below root, sorted. Hidden, vendored and
+// ignored directories and test files are skipped.
+func goEnvNames(root string) []string {
+	matcher := DefaultMatcher(root)
+	found := make(map[string]bool)
+
+	_ = filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, relErr := filepath.Rel(root, path)
+		if relErr != nil || rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		name := entry.Name()
+		if entry.IsDir() {
+			if strings.HasPrefix(name, ".") || skipPackageDirs[name] || matcher.IsExcluded(rel, true) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil
+		}
+		osName := importName(file, "os")
+		if osName == "" {
+			return nil
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 1 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || (sel.Sel.Name != "Getenv" && sel.Sel.Name != "LookupEnv") {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != osName {
+				return true
+			}
+			if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				if value, err := strconv.Unquote(lit.Value); err == nil && value != "" {
+					found[value] = true
+				}
+			}
+			return true
+		})
+		return nil
+	})
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// importName returns the name a Go file uses for the package at path, or ""
+// if the file does not import it
+func importName(file *ast.File, path string) string {
+	for _, spec := range file.Imports {
+		if value, err := strconv.Unquote(spec.Path.Value); err != nil || value != path {
+			continue
+		}
+		if spec.Name != nil {
+			if spec.Name.Name == "_" || spec.Name.Name == "." {
+				return ""
+			}
+			return spec.Name.Name
+		}
+		return filepath.Base(path)
+	}
+	return ""
+}
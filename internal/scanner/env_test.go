@@ -0,0 +1,65 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectEnvVars(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		".env.sample": "IGNORED=1\n",
+		".env.example": `# --- Server ---
+
+# Port to listen on
+PORT=8080
+
+# Connection string for the primary database
+export DATABASE_URL="postgres://localhost/app"
+LOG_LEVEL=info # debug, info or warn
+# OPTIONAL_FLAG=true
+API_KEY=
+PORT=9090
+`,
+		"main.go": `package main
+
+import (
+	"fmt"
+	env "os"
+)
+
+func main() {
+	fmt.Println(env.Getenv("PORT"), env.Getenv("REDIS_URL"))
+	if _, ok := env.LookupEnv("DEBUG"); ok {
+	}
+}
+`,
+		"internal/other.go":  "package internal\n\nfunc Getenv(string) string { return \"\" }\n\nvar _ = Getenv(\"NOT_OS\")\n",
+		"main_test.go":       "package main\n\nimport \"os\"\n\nvar _ = os.Getenv(\"TEST_ONLY\")\n",
+		"vendor/x/x.go":      "package x\n\nimport \"os\"\n\nvar _ = os.Getenv(\"VENDORED\")\n",
+		"cmd/tool/config.go": "package main\n\nimport \"os\"\n\nvar home = os.Getenv(\"APP_HOME\")\n",
+	})
+
+	want := []EnvVar{
+		{Name: "PORT", Default: "8080", Description: "Port to listen on"},
+		{Name: "DATABASE_URL", Default: "postgres://localhost/app", Description: "Connection string for the primary database"},
+		{Name: "LOG_LEVEL", Default: "info", Description: "debug, info or warn"},
+		{Name: "API_KEY"},
+	}
+	files := []string{".env.example", ".env.sample"}
+	if got := DetectEnvVars(root, files, false); !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectEnvVars() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	// Variables read by the Go sources follow the declared ones
+	want = append(want, EnvVar{Name: "APP_HOME"}, EnvVar{Name: "DEBUG"}, EnvVar{Name: "REDIS_URL"})
+	if got := DetectEnvVars(root, files, true); !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectEnvVars(scanGo) =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestDetectEnvVars_NoFile(t *testing.T) {
+	if vars := DetectEnvVars(t.TempDir(), []string{".env.example"}, true); len(vars) != 0 {
+		t.Errorf("expected no variables, got %+v", vars)
+	}
+}
@@ -0,0 +1,242 @@
+package scanner
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// gitInfo is repository metadata read from the local .git directory
+type gitInfo struct {
+	// RepoURL is the web URL of the origin remote (e.g. "https://github.com/owner/repo")
+	RepoURL string
+	// DefaultBranch is the branch origin/HEAD points to, or init.defaultBranch
+	// It is empty when neither is set
+	DefaultBranch string
+	// LatestTag is the highest semantic version tag (e.g. "v1.2.0")
+	LatestTag string
+}
+
+// detectGit reads the repository at root without running git
+// It returns zero values when root is not a git repository
+func detectGit(root string) gitInfo {
+	commonDir, ok := gitCommonDir(root)
+	if !ok {
+		return gitInfo{}
+	}
+
+	var info gitInfo
+	config := readGitConfig(filepath.Join(commonDir, "config"))
+	remote := "origin"
+	if _, ok := config["remote."+remote]; !ok {
+		remote = firstRemote(config)
+	}
+	if remote != "" {
+		info.RepoURL = webURL(config["remote."+remote]["url"])
+		info.DefaultBranch = symbolicRef(filepath.Join(commonDir, "refs", "remotes", remote, "HEAD"), "refs/remotes/"+remote+"/")
+	}
+	if info.DefaultBranch == "" {
+		info.DefaultBranch = config["init"]["defaultbranch"]
+	}
+	info.LatestTag = latestTag(commonDir)
+
+	return info
+}
+
+// gitCommonDir returns the directory holding the config and refs of the
+// repository at root, which differs from its git directory for linked worktrees
+func gitCommonDir(root string) (string, bool) {
+	gitDir := filepath.Join(root, ".git")
+	stat, err := os.Stat(gitDir)
+	if err != nil {
+		return "", false
+	}
+	if !stat.IsDir() {
+		// Worktrees and submodules have a .git file: "gitdir: <path>"
+		content, err := os.ReadFile(gitDir)
+		if err != nil {
+			return "", false
+		}
+		path, found := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir:")
+		if !found {
+			return "", false
+		}
+		gitDir = resolvePath(root, strings.TrimSpace(path))
+	}
+
+	if content, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		return resolvePath(gitDir, strings.TrimSpace(string(content))), true
+	}
+	return gitDir, true
+}
+
+// resolvePath resolves path relative to base unless it is absolute
+func resolvePath(base, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(base, path)
+}
+
+// readGitConfig parses a git config file into sections keyed by name
+// (`[remote "origin"]` becomes "remote.origin"). Keys are lowercased.
+func readGitConfig(path string) map[string]map[string]string {
+	sections := make(map[string]map[string]string)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return sections
+	}
+
+	var current map[string]string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if line[0] == '[' {
+			header := strings.TrimSuffix(strings.TrimPrefix(line, "["), "]")
+			name, sub, hasSub := strings.Cut(header, " ")
+			name = strings.ToLower(name)
+			if hasSub {
+				name += "." + strings.Trim(strings.TrimSpace(sub), `"`)
+			}
+			if sections[name] == nil {
+				sections[name] = make(map[string]string)
+			}
+			current = sections[name]
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		key, value, _ := strings.Cut(line, "=")
+		current[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return sections
+}
+
+// firstRemote returns the alphabetically first remote in config
+func firstRemote(config map[string]map[string]string) string {
+	var remotes []string
+	for section := range config {
+		if name, ok := strings.CutPrefix(section, "remote."); ok {
+			remotes = append(remotes, name)
+		}
+	}
+	if len(remotes) == 0 {
+		return ""
+	}
+	sort.Strings(remotes)
+	return remotes[0]
+}
+
+// scpLikeURL matches remote URLs in scp syntax (git@github.com:owner/repo.git)
+var scpLikeURL = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+// webURL converts a remote URL to the https URL of the repository
+// Local paths (including Windows drives such as C:\repo, whose single letter
+// looks like an scp host) and unknown schemes return ""
+func webURL(remote string) string {
+	var host, path string
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			return ""
+		}
+		switch u.Scheme {
+		case "https", "http", "ssh", "git", "git+ssh":
+		default:
+			return ""
+		}
+		host, path = u.Hostname(), u.Path
+	} else if m := scpLikeURL.FindStringSubmatch(remote); m != nil && len(m[1]) > 1 {
+		host, path = m[1], m[2]
+	} else {
+		return ""
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || path == "" {
+		return ""
+	}
+	return "https://" + host + "/" + path
+}
+
+// symbolicRef returns the target of a symbolic ref file ("ref: refs/heads/main")
+// with prefix removed
+func symbolicRef(path, prefix string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	ref, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "ref: ")
+	if !ok {
+		return ""
+	}
+	return strings.TrimPrefix(ref, prefix)
+}
+
+// semverTag matches version tags such as v1.2.3 and 1.2.3-rc.1
+var semverTag = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?$`)
+
+// latestTag returns the highest semantic version tag in the repository,
+// reading both loose and packed refs
+func latestTag(commonDir string) string {
+	var tags []string
+
+	tagsDir := filepath.Join(commonDir, "refs", "tags")
+	_ = filepath.Walk(tagsDir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			if rel, err := filepath.Rel(tagsDir, path); err == nil {
+				tags = append(tags, filepath.ToSlash(rel))
+			}
+		}
+		return nil
+	})
+
+	if content, err := os.ReadFile(filepath.Join(commonDir, "packed-refs")); err == nil {
+		for _, line := range strings.Split(string(content), "\n") {
+			_, ref, ok := strings.Cut(strings.TrimSpace(line), " ")
+			if tag, isTag := strings.CutPrefix(ref, "refs/tags/"); ok && isTag {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	latest := ""
+	for _, tag := range tags {
+		if semverTag.MatchString(tag) && (latest == "" || compareVersions(tag, latest) > 0) {
+			latest = tag
+		}
+	}
+	return latest
+}
+
+// compareVersions compares two tags matching semverTag. Releases sort after
+// their pre-releases; pre-releases are compared as strings.
+func compareVersions(a, b string) int {
+	ma, mb := semverTag.FindStringSubmatch(a), semverTag.FindStringSubmatch(b)
+	for i := 1; i <= 3; i++ {
+		na, _ := strconv.Atoi(ma[i])
+		nb, _ := strconv.Atoi(mb[i])
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case ma[4] == mb[4]:
+		return 0
+	case ma[4] == "":
+		return 1
+	case mb[4] == "":
+		return -1
+	}
+	return strings.Compare(ma[4], mb[4])
+}
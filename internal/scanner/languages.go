@@ -0,0 +1,70 @@
+package scanner
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// languageExtensions maps source file extensions to languages
+var languageExtensions = map[string]string{
+	".go":   "go",
+	".ts":   "typescript",
+	".tsx":  "typescript",
+	".mts":  "typescript",
+	".cts":  "typescript",
+	".js":   "javascript",
+	".jsx":  "javascript",
+	".mjs":  "javascript",
+	".cjs":  "javascript",
+	".rs":   "rust",
+	".py":   "python",
+	".java": "java",
+	".kt":   "kotlin",
+	".php":  "php",
+	".rb":   "ruby",
+	".cs":   "csharp",
+}
+
+// rankLanguages counts the source files below root by language, like GitHub's
+// linguist does by size, and returns the languages with the most files first.
+// Hidden, ignored and vendored directories are skipped.
+func rankLanguages(root string) []string {
+	matcher := DefaultMatcher(root)
+
+	counts := make(map[string]int)
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == root {
+			return nil
+		}
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if d.IsDir() {
+			if strings.HasPrefix(d.Name(), ".") || skipPackageDirs[d.Name()] || matcher.IsExcluded(relPath, true) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if lang, ok := languageExtensions[filepath.Ext(d.Name())]; ok && !matcher.IsExcluded(relPath, false) {
+			counts[lang]++
+		}
+		return nil
+	})
+
+	languages := make([]string, 0, len(counts))
+	for lang := range counts {
+		languages = append(languages, lang)
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		if counts[languages[i]] != counts[languages[j]] {
+			return counts[languages[i]] > counts[languages[j]]
+		}
+		return languages[i] < languages[j]
+	})
+	return languages
+}
@@ -0,0 +1,73 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// licenseFiles are the file names checked for the project license, in order
+var licenseFiles = []string{
+	"LICENSE",
+	"LICENSE.md",
+	"LICENSE.txt",
+	"LICENCE",
+	"LICENCE.md",
+	"COPYING",
+}
+
+// licenseRule identifies a license by phrases that all appear in its text
+type licenseRule struct {
+	spdx    string
+	phrases []string
+}
+
+// licenseRules are tried in order. Licenses that mention others (the MPL
+// lists the GPLs, the GPL mentions the AGPL) are matched by their title.
+var licenseRules = []licenseRule{
+	{"MPL-2.0", []string{"mozilla public license version 2.0"}},
+	{"AGPL-3.0", []string{"gnu affero general public license version 3"}},
+	{"LGPL-3.0", []string{"gnu lesser general public license version 3"}},
+	{"LGPL-2.1", []string{"gnu lesser general public license version 2.1"}},
+	{"GPL-3.0", []string{"gnu general public license version 3"}},
+	{"GPL-2.0", []string{"gnu general public license version 2"}},
+	{"Apache-2.0", []string{"apache license", "version 2.0"}},
+	{"BSL-1.0", []string{"boost software license - version 1.0"}},
+	{"Unlicense", []string{"this is free and unencumbered software released into the public domain"}},
+	{"ISC", []string{"permission to use, copy, modify, and/or distribute this software for any purpose with or without fee is hereby granted"}},
+	{"MIT", []string{"permission is hereby granted, free of charge"}},
+	{"BSD-3-Clause", []string{"redistribution and use in source and binary forms", "neither the name"}},
+	{"BSD-2-Clause", []string{"redistribution and use in source and binary forms"}},
+}
+
+// detectLicense returns the SPDX identifier of the license in root and the
+// name of its file. The identifier is empty when the text is not recognized.
+func detectLicense(root string) (spdx, file string) {
+	for _, name := range licenseFiles {
+		content, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			continue
+		}
+		return identifyLicense(string(content)), name
+	}
+	return "", ""
+}
+
+// identifyLicense returns the SPDX identifier matching a license text
+func identifyLicense(text string) string {
+	// Compare case-insensitively with line breaks collapsed
+	text = strings.ToLower(strings.Join(strings.Fields(text), " "))
+	for _, rule := range licenseRules {
+		matched := true
+		for _, phrase := range rule.phrases {
+			if !strings.Contains(text, phrase) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rule.spdx
+		}
+	}
+	return ""
+}
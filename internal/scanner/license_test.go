@@ -0,0 +1,45 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIdentifyLicense(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"MIT License\n\nPermission is hereby granted, free of\ncharge, to any person", "MIT"},
+		{"                                 Apache License\n                           Version 2.0, January 2004", "Apache-2.0"},
+		{"GNU GENERAL PUBLIC LICENSE\n   Version 3, 29 June 2007\n... GNU Affero General Public License ...", "GPL-3.0"},
+		{"GNU AFFERO GENERAL PUBLIC LICENSE\n   Version 3, 19 November 2007", "AGPL-3.0"},
+		{"GNU LESSER GENERAL PUBLIC LICENSE\n   Version 2.1, February 1999\n GNU General Public License", "LGPL-2.1"},
+		{"Mozilla Public License Version 2.0\n... GNU General Public License, Version 2.0 ...", "MPL-2.0"},
+		{"Redistribution and use in source and binary forms ... Neither the name of the copyright holder", "BSD-3-Clause"},
+		{"Redistribution and use in source and binary forms, with or without modification", "BSD-2-Clause"},
+		{"This is free and unencumbered software released into the public domain.", "Unlicense"},
+		{"All rights reserved.", ""},
+	}
+
+	for _, tt := range tests {
+		if got := identifyLicense(tt.text); got != tt.want {
+			t.Errorf("identifyLicense(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestDetectLicense_File(t *testing.T) {
+	root := t.TempDir()
+	if spdx, file := detectLicense(root); spdx != "" || file != "" {
+		t.Errorf("expected no license, got %q %q", spdx, file)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "LICENSE.md"), []byte("Apache License\nVersion 2.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if spdx, file := detectLicense(root); spdx != "Apache-2.0" || file != "LICENSE.md" {
+		t.Errorf("detectLicense() = %q %q, want Apache-2.0 LICENSE.md", spdx, file)
+	}
+}
@@ -0,0 +1,540 @@
+package scanner
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Ecosystems reported in Manifest.Ecosystem
+const (
+	EcosystemGo       = "go"
+	EcosystemNpm      = "npm"
+	EcosystemDeno     = "deno"
+	EcosystemCargo    = "cargo"
+	EcosystemPyPI     = "pypi"
+	EcosystemMaven    = "maven"
+	EcosystemGradle   = "gradle"
+	EcosystemComposer = "composer"
+	EcosystemRubyGems = "rubygems"
+	EcosystemNuGet    = "nuget"
+)
+
+// ecosystemNames are the display names of the ecosystems
+var ecosystemNames = map[string]string{
+	EcosystemGo:       "Go",
+	EcosystemNpm:      "npm",
+	EcosystemDeno:     "Deno",
+	EcosystemCargo:    "Cargo",
+	EcosystemPyPI:     "PyPI",
+	EcosystemMaven:    "Maven",
+	EcosystemGradle:   "Gradle",
+	EcosystemComposer: "Composer",
+	EcosystemRubyGems: "RubyGems",
+	EcosystemNuGet:    "NuGet",
+}
+
+// ecosystemLanguages are the languages an ecosystem's packages may be written
+// in, besides the one its manifest reports
+var ecosystemLanguages = map[string][]string{
+	EcosystemNpm:    {"javascript", "typescript"},
+	EcosystemDeno:   {"typescript", "javascript"},
+	EcosystemMaven:  {"java", "kotlin"},
+	EcosystemGradle: {"java", "kotlin"},
+}
+
+// Manifest is the project metadata read from one package manifest
+type Manifest struct {
+	// File is the manifest file name (e.g. "go.mod")
+	File string
+	// Ecosystem is the package ecosystem (go, npm, cargo, ...)
+	Ecosystem   string
+	Language    string
+	Name        string
+	Description string
+	Version     string
+	// ModulePath is the Go module path, Maven coordinates (group:artifact)
+	// or Composer package name (vendor/package)
+	ModulePath string
+	// Private reports that the package is not published ("private": true in package.json)
+	Private bool
+}
+
+// DisplayName returns the name of the manifest's ecosystem for headings (e.g. "Cargo")
+func (m Manifest) DisplayName() string {
+	if name, ok := ecosystemNames[m.Ecosystem]; ok {
+		return name
+	}
+	return m.Ecosystem
+}
+
+// writtenIn reports whether the manifest's package may be written in lang
+func (m Manifest) writtenIn(lang string) bool {
+	if m.Language == lang {
+		return true
+	}
+	for _, l := range ecosystemLanguages[m.Ecosystem] {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// manifestDetector reads project metadata from a manifest in dir
+// It reports false when dir has no manifest of its kind
+type manifestDetector func(dir string) (Manifest, bool)
+
+// manifestDetectors are tried in order
+var manifestDetectors = []manifestDetector{
+	detectGoMod,
+	detectPackageJSON,
+	detectDenoJSON,
+	detectCargoToml,
+	detectPython,
+	detectMaven,
+	detectGradle,
+	detectComposerJSON,
+	detectRuby,
+	detectCsproj,
+}
+
+// detectManifests returns the metadata of every manifest in dir, in detector order
+func detectManifests(dir string) []Manifest {
+	var manifests []Manifest
+	for _, detect := range manifestDetectors {
+		if m, ok := detect(dir); ok {
+			manifests = append(manifests, m)
+		}
+	}
+	return manifests
+}
+
+// projectInfo combines the manifests of a project. The primary manifest is the
+// first one written in the highest ranked language; it supplies the name,
+// version and module path. Without a ranking the first manifest is primary.
+func projectInfo(root string, manifests []Manifest, languages []string) ProjectInfo {
+	info := ProjectInfo{
+		Name:      filepath.Base(absPath(root)),
+		Languages: languages,
+	}
+	if len(languages) > 0 {
+		info.Language = languages[0]
+	}
+	if len(manifests) == 0 {
+		return info
+	}
+
+	primary := primaryManifest(manifests, languages)
+	info.Ecosystems = append([]Manifest{manifests[primary]}, manifests[:primary]...)
+	info.Ecosystems = append(info.Ecosystems, manifests[primary+1:]...)
+
+	m := info.Ecosystems[0]
+	if info.Language == "" {
+		info.Language = m.Language
+	}
+	info.Ecosystem = m.Ecosystem
+	info.Version = m.Version
+	info.ModulePath = m.ModulePath
+	if m.Name != "" {
+		info.Name = m.Name
+	}
+	// go.mod has no description, so borrow one from another manifest
+	for _, e := range info.Ecosystems {
+		if e.Description != "" {
+			info.Description = e.Description
+			break
+		}
+	}
+
+	return info
+}
+
+// primaryManifest returns the index of the first manifest written in the
+// highest ranked language that has one
+func primaryManifest(manifests []Manifest, languages []string) int {
+	for _, lang := range languages {
+		for i, m := range manifests {
+			if m.writtenIn(lang) {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+// detectGoMod reads go.mod
+func detectGoMod(dir string) (Manifest, bool) {
+	content, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return Manifest{}, false
+	}
+
+	info := Manifest{File: "go.mod", Language: "go", Ecosystem: EcosystemGo}
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, "module ") {
+			info.ModulePath = strings.TrimSpace(strings.TrimPrefix(line, "module "))
+			// Extract name from module path
+			parts := strings.Split(info.ModulePath, "/")
+			info.Name = parts[len(parts)-1]
+			break
+		}
+	}
+	return info, true
+}
+
+// detectPackageJSON reads package.json
+func detectPackageJSON(dir string) (Manifest, bool) {
+	content, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return Manifest{}, false
+	}
+
+	info := Manifest{File: "package.json", Language: "javascript", Ecosystem: EcosystemNpm}
+	var pkg struct {
+		Name            string            `json:"name"`
+		Description     string            `json:"description"`
+		Version         string            `json:"version"`
+		Private         bool              `json:"private"`
+		Types           string            `json:"types"`
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if json.Unmarshal(content, &pkg) == nil {
+		info.Name = pkg.Name
+		info.Description = pkg.Description
+		info.Version = pkg.Version
+		info.Private = pkg.Private
+		if pkg.Types != "" || pkg.Dependencies["typescript"] != "" || pkg.DevDependencies["typescript"] != "" {
+			info.Language = "typescript"
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "tsconfig.json")); err == nil {
+		info.Language = "typescript"
+	}
+	return info, true
+}
+
+// detectDenoJSON reads deno.json or deno.jsonc
+func detectDenoJSON(dir string) (Manifest, bool) {
+	for _, file := range []string{"deno.json", "deno.jsonc"} {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			continue
+		}
+
+		info := Manifest{File: file, Language: "typescript", Ecosystem: EcosystemDeno}
+		var pkg struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		}
+		if json.Unmarshal(stripJSONComments(content), &pkg) == nil {
+			info.Name = pkg.Name
+			info.Version = pkg.Version
+		}
+		return info, true
+	}
+	return Manifest{}, false
+}
+
+// detectCargoToml reads the [package] table of Cargo.toml
+func detectCargoToml(dir string) (Manifest, bool) {
+	content, err := os.ReadFile(filepath.Join(dir, "Cargo.toml"))
+	if err != nil {
+		return Manifest{}, false
+	}
+
+	pkg := sectionValues(string(content), "package")
+	return Manifest{
+		Name:        pkg["name"],
+		Description: pkg["description"],
+		Version:     pkg["version"],
+		Language:    "rust",
+		Ecosystem:   EcosystemCargo,
+		File:        "Cargo.toml",
+	}, true
+}
+
+// detectPython reads pyproject.toml ([project], then [tool.poetry]) or
+// the [metadata] section of setup.cfg
+func detectPython(dir string) (Manifest, bool) {
+	info := Manifest{Language: "python", Ecosystem: EcosystemPyPI}
+
+	if content, err := os.ReadFile(filepath.Join(dir, "pyproject.toml")); err == nil {
+		info.File = "pyproject.toml"
+		for _, section := range []string{"project", "tool.poetry"} {
+			values := sectionValues(string(content), section)
+			if values["name"] != "" {
+				info.Name = values["name"]
+				info.Description = values["description"]
+				info.Version = values["version"]
+				break
+			}
+		}
+		return info, true
+	}
+
+	if content, err := os.ReadFile(filepath.Join(dir, "setup.cfg")); err == nil {
+		info.File = "setup.cfg"
+		values := sectionValues(string(content), "metadata")
+		info.Name = values["name"]
+		info.Description = values["description"]
+		info.Version = values["version"]
+		return info, true
+	}
+
+	return Manifest{}, false
+}
+
+// detectMaven reads pom.xml
+// ModulePath is set to the Maven coordinates (groupId:artifactId)
+func detectMaven(dir string) (Manifest, bool) {
+	content, err := os.ReadFile(filepath.Join(dir, "pom.xml"))
+	if err != nil {
+		return Manifest{}, false
+	}
+
+	info := Manifest{File: "pom.xml", Language: "java", Ecosystem: EcosystemMaven}
+	var pom struct {
+		GroupID     string `xml:"groupId"`
+		ArtifactID  string `xml:"artifactId"`
+		Version     string `xml:"version"`
+		Description string `xml:"description"`
+		Parent      struct {
+			GroupID string `xml:"groupId"`
+		} `xml:"parent"`
+	}
+	if xml.Unmarshal(content, &pom) == nil {
+		groupID := pom.GroupID
+		if groupID == "" {
+			groupID = pom.Parent.GroupID
+		}
+		info.Name = pom.ArtifactID
+		info.Description = strings.TrimSpace(pom.Description)
+		// Versions like ${revision} are resolved by Maven at build time
+		if !strings.Contains(pom.Version, "${") {
+			info.Version = pom.Version
+		}
+		if groupID != "" && pom.ArtifactID != "" {
+			info.ModulePath = groupID + ":" + pom.ArtifactID
+		}
+	}
+	return info, true
+}
+
+var (
+	gradleRootNamePattern    = regexp.MustCompile(`(?m)^\s*rootProject\.name\s*=\s*["']([^"']+)["']`)
+	gradleGroupPattern       = regexp.MustCompile(`(?m)^\s*group\s*=\s*["']([^"']+)["']`)
+	gradleVersionPattern     = regexp.MustCompile(`(?m)^\s*version\s*=\s*["']([^"']+)["']`)
+	gradleDescriptionPattern = regexp.MustCompile(`(?m)^\s*description\s*=\s*["']([^"']+)["']`)
+)
+
+// detectGradle reads build.gradle(.kts) and the project name from settings.gradle(.kts)
+// ModulePath is set to the Maven coordinates (group:name) when a group is declared
+func detectGradle(dir string) (Manifest, bool) {
+	file, build, ok := readFirst(dir, "build.gradle.kts", "build.gradle")
+	if !ok {
+		return Manifest{}, false
+	}
+
+	info := Manifest{
+		File:        file,
+		Description: firstMatch(gradleDescriptionPattern, build),
+		Version:     firstMatch(gradleVersionPattern, build),
+		Language:    "java",
+		Ecosystem:   EcosystemGradle,
+	}
+	if _, settings, ok := readFirst(dir, "settings.gradle.kts", "settings.gradle"); ok {
+		info.Name = firstMatch(gradleRootNamePattern, settings)
+	}
+	if group := firstMatch(gradleGroupPattern, build); group != "" {
+		name := info.Name
+		if name == "" {
+			name = filepath.Base(absPath(dir))
+		}
+		info.ModulePath = group + ":" + name
+	}
+	return info, true
+}
+
+// detectComposerJSON reads composer.json
+// ModulePath is set to the full package name (vendor/package)
+func detectComposerJSON(dir string) (Manifest, bool) {
+	content, err := os.ReadFile(filepath.Join(dir, "composer.json"))
+	if err != nil {
+		return Manifest{}, false
+	}
+
+	info := Manifest{File: "composer.json", Language: "php", Ecosystem: EcosystemComposer}
+	var pkg struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Version     string `json:"version"`
+	}
+	if json.Unmarshal(content, &pkg) == nil {
+		info.ModulePath = pkg.Name
+		info.Name = pkg.Name[strings.LastIndex(pkg.Name, "/")+1:]
+		info.Description = pkg.Description
+		info.Version = pkg.Version
+	}
+	return info, true
+}
+
+var (
+	gemNamePattern    = regexp.MustCompile(`(?m)^\s*\w+\.name\s*=\s*["']([^"']+)["']`)
+	gemSummaryPattern = regexp.MustCompile(`(?m)^\s*\w+\.summary\s*=\s*["']([^"']+)["']`)
+	gemVersionPattern = regexp.MustCompile(`(?m)^\s*\w+\.version\s*=\s*["']([^"']+)["']`)
+)
+
+// detectRuby reads the first *.gemspec, or recognizes an application by its Gemfile
+func detectRuby(dir string) (Manifest, bool) {
+	info := Manifest{Language: "ruby", Ecosystem: EcosystemRubyGems}
+
+	if specs, _ := filepath.Glob(filepath.Join(dir, "*.gemspec")); len(specs) > 0 {
+		info.File = filepath.Base(specs[0])
+		content, err := os.ReadFile(specs[0])
+		if err != nil {
+			return info, true
+		}
+		info.Name = firstMatch(gemNamePattern, string(content))
+		if info.Name == "" {
+			info.Name = strings.TrimSuffix(filepath.Base(specs[0]), ".gemspec")
+		}
+		info.Description = firstMatch(gemSummaryPattern, string(content))
+		info.Version = firstMatch(gemVersionPattern, string(content))
+		return info, true
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "Gemfile")); err == nil {
+		info.File = "Gemfile"
+		return info, true
+	}
+
+	return Manifest{}, false
+}
+
+// detectCsproj reads the first *.csproj
+func detectCsproj(dir string) (Manifest, bool) {
+	projects, _ := filepath.Glob(filepath.Join(dir, "*.csproj"))
+	if len(projects) == 0 {
+		return Manifest{}, false
+	}
+
+	info := Manifest{
+		File:      filepath.Base(projects[0]),
+		Name:      strings.TrimSuffix(filepath.Base(projects[0]), ".csproj"),
+		Language:  "csharp",
+		Ecosystem: EcosystemNuGet,
+	}
+	content, err := os.ReadFile(projects[0])
+	if err != nil {
+		return info, true
+	}
+
+	var project struct {
+		PropertyGroups []struct {
+			PackageID    string `xml:"PackageId"`
+			AssemblyName string `xml:"AssemblyName"`
+			Description  string `xml:"Description"`
+			Version      string `xml:"Version"`
+		} `xml:"PropertyGroup"`
+	}
+	if xml.Unmarshal(content, &project) != nil {
+		return info, true
+	}
+	for _, group := range project.PropertyGroups {
+		switch {
+		case group.PackageID != "":
+			info.Name = group.PackageID
+		case group.AssemblyName != "":
+			info.Name = group.AssemblyName
+		}
+		if group.Description != "" {
+			info.Description = group.Description
+		}
+		if group.Version != "" {
+			info.Version = group.Version
+		}
+	}
+	return info, true
+}
+
+// sectionValues returns the string values of a section of a TOML or INI
+// file (e.g. "package" in Cargo.toml). Only single-line `key = value`
+// pairs are read; quotes around values are removed.
+func sectionValues(content, section string) map[string]string {
+	values := make(map[string]string)
+	inSection := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if line[0] == '[' {
+			inSection = strings.TrimSpace(strings.Trim(line, "[]")) == section
+			continue
+		}
+		if !inSection {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		values[strings.TrimSpace(key)] = unquoteValue(strings.TrimSpace(value))
+	}
+	return values
+}
+
+// unquoteValue removes the quotes around a TOML string and drops trailing
+// comments from unquoted values
+func unquoteValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	if quote := value[0]; quote == '"' || quote == '\'' {
+		if end := strings.IndexByte(value[1:], quote); end >= 0 {
+			return value[1 : end+1]
+		}
+		return value[1:]
+	}
+	for _, marker := range []string{" #", " ;"} {
+		if i := strings.Index(value, marker); i >= 0 {
+			value = value[:i]
+		}
+	}
+	return strings.TrimSpace(value)
+}
+
+// stripJSONComments removes whole-line // comments from JSONC content
+func stripJSONComments(content []byte) []byte {
+	lines := strings.Split(string(content), "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "//") {
+			kept = append(kept, line)
+		}
+	}
+	return []byte(strings.Join(kept, "\n"))
+}
+
+// readFirst returns the name and content of the first file in dir that exists
+func readFirst(dir string, files ...string) (string, string, bool) {
+	for _, file := range files {
+		if content, err := os.ReadFile(filepath.Join(dir, file)); err == nil {
+			return file, string(content), true
+		}
+	}
+	return "", "", false
+}
+
+// firstMatch returns the first submatch of pattern in content
+func firstMatch(pattern *regexp.Regexp, content string) string {
+	if m := pattern.FindStringSubmatch(content); m != nil {
+		return m[1]
+	}
+	return ""
+}
@@ -0,0 +1,251 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hulk510/readme-gen/internal/testutil"
+)
+
+func TestDetectProjectInfo_Ecosystems(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  ProjectInfo
+	}{
+		{
+			name: "cargo",
+			files: map[string]string{"Cargo.toml": `[package]
+name = "ripper"
+version = "0.3.1" # bumped by release
+description = "Fast file ripper"
+
+[dependencies]
+name = "not-this"
+`},
+			want: ProjectInfo{Name: "ripper", Version: "0.3.1", Description: "Fast file ripper", Language: "rust", Ecosystem: EcosystemCargo},
+		},
+		{
+			name: "pyproject",
+			files: map[string]string{"pyproject.toml": `[project]
+name = 'pyproj'
+version = "1.0.0"
+description = "Python project"
+`},
+			want: ProjectInfo{Name: "pyproj", Version: "1.0.0", Description: "Python project", Language: "python", Ecosystem: EcosystemPyPI},
+		},
+		{
+			name: "poetry",
+			files: map[string]string{"pyproject.toml": `[tool.poetry]
+name = "poetic"
+version = "2.0.0"
+`},
+			want: ProjectInfo{Name: "poetic", Version: "2.0.0", Language: "python", Ecosystem: EcosystemPyPI},
+		},
+		{
+			name: "setup.cfg",
+			files: map[string]string{"setup.cfg": `[metadata]
+name = legacy
+version = 0.1
+description = Legacy setup
+`},
+			want: ProjectInfo{Name: "legacy", Version: "0.1", Description: "Legacy setup", Language: "python", Ecosystem: EcosystemPyPI},
+		},
+		{
+			name: "maven",
+			files: map[string]string{"pom.xml": `<project>
+  <parent><groupId>org.parent</groupId><artifactId>parent</artifactId><version>9</version></parent>
+  <artifactId>widget</artifactId>
+  <version>1.4.0</version>
+  <description>Widget library</description>
+</project>`},
+			want: ProjectInfo{Name: "widget", Version: "1.4.0", Description: "Widget library", Language: "java", Ecosystem: EcosystemMaven, ModulePath: "org.parent:widget"},
+		},
+		{
+			name: "gradle",
+			files: map[string]string{
+				"build.gradle.kts":    "group = \"com.acme\"\nversion = \"0.9.0\"\n",
+				"settings.gradle.kts": "rootProject.name = \"rocket\"\n",
+			},
+			want: ProjectInfo{Name: "rocket", Version: "0.9.0", Language: "java", Ecosystem: EcosystemGradle, ModulePath: "com.acme:rocket"},
+		},
+		{
+			name:  "composer",
+			files: map[string]string{"composer.json": `{"name": "acme/http", "description": "HTTP client"}`},
+			want:  ProjectInfo{Name: "http", Description: "HTTP client", Language: "php", Ecosystem: EcosystemComposer, ModulePath: "acme/http"},
+		},
+		{
+			name: "gemspec",
+			files: map[string]string{"gem.gemspec": `Gem::Specification.new do |spec|
+  spec.name    = "shiny"
+  spec.version = Shiny::VERSION
+  spec.summary = "Shiny things"
+end
+`},
+			want: ProjectInfo{Name: "shiny", Description: "Shiny things", Language: "ruby", Ecosystem: EcosystemRubyGems},
+		},
+		{
+			name:  "deno",
+			files: map[string]string{"deno.jsonc": "{\n  // JSR package\n  \"name\": \"@acme/kit\",\n  \"version\": \"0.2.0\"\n}\n"},
+			want:  ProjectInfo{Name: "@acme/kit", Version: "0.2.0", Language: "typescript", Ecosystem: EcosystemDeno},
+		},
+		{
+			name: "csproj",
+			files: map[string]string{"Acme.Core.csproj": `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup>
+  <PropertyGroup>
+    <PackageId>Acme.Core</PackageId>
+    <Version>3.1.0</Version>
+    <Description>Core types</Description>
+  </PropertyGroup>
+</Project>`},
+			want: ProjectInfo{Name: "Acme.Core", Version: "3.1.0", Description: "Core types", Language: "csharp", Ecosystem: EcosystemNuGet},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got := DetectProjectInfo(root)
+			if got.Name != tt.want.Name || got.Version != tt.want.Version || got.Description != tt.want.Description ||
+				got.Language != tt.want.Language || got.Ecosystem != tt.want.Ecosystem || got.ModulePath != tt.want.ModulePath {
+				t.Errorf("DetectProjectInfo() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectProjectInfo_GemfileOnly(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "Gemfile"), []byte("source \"https://rubygems.org\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := DetectProjectInfo(root)
+	if got.Language != "ruby" || got.Name != filepath.Base(root) {
+		t.Errorf("DetectProjectInfo() = %+v, want ruby project named after the directory", got)
+	}
+}
+
+func TestDetectProjectInfo_Polyglot(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":             "module github.com/example/tool\n",
+		"package.json":       `{"name": "tool-docs", "description": "Docs site tooling"}`,
+		"main.go":            "package main\n",
+		"internal/a/a.go":    "package a\n",
+		"internal/b/b.go":    "package b\n",
+		"web/app.ts":         "export {}\n",
+		"web/vendor/lib.ts":  "export {}\n",
+		"node_modules/x.js":  "",
+		".github/scripts.js": "",
+	}
+	writeFiles(t, root, files)
+
+	info := DetectProjectInfo(root)
+	if info.Language != "go" || info.Ecosystem != EcosystemGo {
+		t.Errorf("expected primary go, got language %q ecosystem %q", info.Language, info.Ecosystem)
+	}
+	if info.Name != "tool" || info.ModulePath != "github.com/example/tool" {
+		t.Errorf("expected name and module path from go.mod, got %q %q", info.Name, info.ModulePath)
+	}
+	if info.Description != "Docs site tooling" {
+		t.Errorf("expected description from package.json, got %q", info.Description)
+	}
+	if got := strings.Join(info.Languages, ","); got != "go,typescript" {
+		t.Errorf("Languages = %s, want go,typescript", got)
+	}
+	if len(info.Ecosystems) != 2 || info.Ecosystems[0].Ecosystem != EcosystemGo || info.Ecosystems[1].Ecosystem != EcosystemNpm {
+		t.Errorf("Ecosystems = %+v, want go then npm", info.Ecosystems)
+	}
+}
+
+func TestDetectProjectInfo_PrimaryFromFileCount(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod":         "module github.com/example/tools\n",
+		"package.json":   `{"name": "web-app"}`,
+		"tools/gen.go":   "package main\n",
+		"src/index.js":   "",
+		"src/app.js":     "",
+		"src/routes.jsx": "",
+	})
+
+	info := DetectProjectInfo(root)
+	if info.Language != "javascript" || info.Name != "web-app" || info.Ecosystem != EcosystemNpm {
+		t.Errorf("expected javascript project web-app, got %+v", info)
+	}
+	if info.Ecosystems[1].ModulePath != "github.com/example/tools" {
+		t.Errorf("expected go.mod to stay in Ecosystems, got %+v", info.Ecosystems)
+	}
+}
+
+func TestDetectProjectInfo_PrimaryLanguageSet(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		ecosystem string
+	}{
+		{"kotlin gradle with go tooling", map[string]string{
+			"go.mod":                     "module github.com/example/tools\n",
+			"build.gradle.kts":           "",
+			"tools/gen.go":               "package main\n",
+			"src/main/kotlin/App.kt":     "",
+			"src/main/kotlin/Routes.kt":  "",
+			"src/test/kotlin/AppTest.kt": "",
+		}, EcosystemGradle},
+		{"typescript npm with go tooling", map[string]string{
+			"go.mod":        "module github.com/example/tools\n",
+			"package.json":  `{"name": "web-app"}`,
+			"tools/gen.go":  "package main\n",
+			"src/index.ts":  "",
+			"src/app.ts":    "",
+			"src/routes.ts": "",
+		}, EcosystemNpm},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, tt.files)
+			info := DetectProjectInfo(root)
+			if info.Ecosystem != tt.ecosystem {
+				t.Errorf("Ecosystem = %q, want %q (%+v)", info.Ecosystem, tt.ecosystem, info)
+			}
+		})
+	}
+}
+
+func TestDetectPackageJSON_Language(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"plain", map[string]string{"package.json": `{"name": "x"}`}, "javascript"},
+		{"tsconfig", map[string]string{"package.json": `{"name": "x"}`, "tsconfig.json": "{}"}, "typescript"},
+		{"dependency", map[string]string{"package.json": `{"devDependencies": {"typescript": "^5.0.0"}}`}, "typescript"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, tt.files)
+			m, ok := detectPackageJSON(root)
+			if !ok || m.Language != tt.want {
+				t.Errorf("detectPackageJSON() = %+v, want language %s", m, tt.want)
+			}
+		})
+	}
+}
+
+// writeFiles creates the fixture files of a test below root
+var writeFiles = testutil.WriteFiles
@@ -3,7 +3,9 @@ package scanner
 import (
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/hulk510/readme-gen/internal/backup"
 	"github.com/hulk510/readme-gen/internal/config"
 	ignore "github.com/sabhiram/go-gitignore"
 )
@@ -15,6 +17,28 @@ type Matcher struct {
 	extraExclude *ignore.GitIgnore
 	includes     map[string]bool
 	maxDepth     int
+	base         PathRule
+	paths        map[string]config.PathConfig
+	sortMode     string
+	dirsFirst    bool
+	pins         map[string]int
+}
+
+// PathRule holds the effective settings for a directory after applying
+// global settings and per-path overrides
+type PathRule struct {
+	// MaxDepth is the number of levels shown below DepthBase (0 = use global max_depth)
+	MaxDepth int
+	// DepthBase is the path the MaxDepth override was declared on
+	DepthBase string
+	// MaxChildren limits the number of rendered children (0 = unlimited)
+	MaxChildren int
+	// Files includes files, not only directories
+	Files bool
+	// Hidden includes dot-prefixed entries
+	Hidden bool
+	// Collapse hides the directory's contents
+	Collapse bool
 }
 
 // NewMatcher creates a new Matcher from configuration
@@ -23,6 +47,23 @@ func NewMatcher(root string, cfg *config.Config) *Matcher {
 		root:     root,
 		includes: make(map[string]bool),
 		maxDepth: cfg.Structure.MaxDepth,
+		base: PathRule{
+			MaxChildren: cfg.Structure.MaxChildren,
+			Files:       cfg.Structure.Files,
+			Hidden:      cfg.Structure.Hidden,
+		},
+		paths:     cfg.Structure.Paths,
+		sortMode:  cfg.Structure.Sort,
+		dirsFirst: cfg.Structure.DirsFirst,
+		pins:      make(map[string]int),
+	}
+
+	// Build pin order
+	for i, p := range cfg.Structure.Pin {
+		p = config.NormalizePath(p)
+		if _, ok := m.pins[p]; !ok {
+			m.pins[p] = i
+		}
 	}
 
 	// Load .gitignore if enabled
@@ -54,7 +95,7 @@ func (m *Matcher) IsExcluded(relPath string, isDir bool) bool {
 	name := filepath.Base(relPath)
 
 	// Check if explicitly included (overrides everything)
-	if m.includes[name] || m.includes[relPath] {
+	if m.IsIncluded(relPath) {
 		return false
 	}
 
@@ -63,6 +104,15 @@ func (m *Matcher) IsExcluded(relPath string, isDir bool) bool {
 		return true
 	}
 
+	// readme-gen's backups, and the work directory itself while it holds
+	// nothing else (user templates stay listed)
+	switch filepath.ToSlash(relPath) {
+	case backup.Dir:
+		return true
+	case config.WorkDir:
+		return !m.hasWorkFiles()
+	}
+
 	// Check .gitignore patterns
 	if m.gitignore != nil {
 		// go-gitignore expects paths relative to .gitignore location
@@ -89,11 +139,83 @@ func (m *Matcher) IsExcluded(relPath string, isDir bool) bool {
 	return false
 }
 
+// IsIncluded reports whether a path matches an explicit include pattern (!pattern)
+func (m *Matcher) IsIncluded(relPath string) bool {
+	return m.includes[filepath.Base(relPath)] || m.includes[relPath]
+}
+
 // MaxDepth returns the configured max depth (0 = unlimited)
 func (m *Matcher) MaxDepth() int {
 	return m.maxDepth
 }
 
+// MaxChildren returns the max number of children rendered for the directory
+// at relPath ("" for the root), honoring per-path overrides (0 = unlimited)
+func (m *Matcher) MaxChildren(relPath string) int {
+	return m.Rule(relPath).MaxChildren
+}
+
+// Rule resolves the effective settings for the directory at relPath
+// ("" for the root). Overrides are applied from the root down, so the
+// most specific path wins.
+func (m *Matcher) Rule(relPath string) PathRule {
+	rule := m.base
+	if len(m.paths) == 0 {
+		return rule
+	}
+
+	for _, p := range ancestors(relPath) {
+		pc, ok := m.paths[p]
+		if !ok {
+			continue
+		}
+		if pc.MaxDepth > 0 {
+			rule.MaxDepth = pc.MaxDepth
+			rule.DepthBase = p
+		}
+		if pc.MaxChildren > 0 {
+			rule.MaxChildren = pc.MaxChildren
+		}
+		if pc.Files != nil {
+			rule.Files = *pc.Files
+		}
+		if pc.Hidden != nil {
+			rule.Hidden = *pc.Hidden
+		}
+		// Collapse only applies to the path it is declared on
+		rule.Collapse = p == relPath && pc.Collapse
+	}
+
+	return rule
+}
+
+// hasWorkFiles reports whether the work directory holds anything besides backups
+func (m *Matcher) hasWorkFiles() bool {
+	entries, err := os.ReadDir(filepath.Join(m.root, config.WorkDir))
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if config.WorkDir+"/"+entry.Name() != backup.Dir {
+			return true
+		}
+	}
+	return false
+}
+
+// ancestors returns relPath and all of its parents, starting from the root ("")
+func ancestors(relPath string) []string {
+	result := []string{""}
+	if relPath == "" {
+		return result
+	}
+	parts := strings.Split(relPath, "/")
+	for i := range parts {
+		result = append(result, strings.Join(parts[:i+1], "/"))
+	}
+	return result
+}
+
 // DefaultMatcher returns a matcher with default settings (no config file)
 func DefaultMatcher(root string) *Matcher {
 	cfg := config.Default()
@@ -106,6 +228,7 @@ func LegacyMatcher(root string, excludes []string) *Matcher {
 	m := &Matcher{
 		root:     root,
 		includes: make(map[string]bool),
+		base:     PathRule{Hidden: true},
 	}
 
 	if len(excludes) > 0 {
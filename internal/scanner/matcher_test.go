@@ -212,3 +212,103 @@ func containsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestMatcher_Rule(t *testing.T) {
+	tmpDir := t.TempDir()
+	showFiles := true
+	noHidden := false
+
+	cfg := config.Default()
+	cfg.Structure.MaxChildren = 10
+	cfg.Structure.Paths = map[string]config.PathConfig{
+		"internal":     {MaxDepth: 3, Files: &showFiles},
+		"internal/gen": {MaxChildren: 2, Hidden: &noHidden},
+		"web":          {Collapse: true},
+	}
+	matcher := NewMatcher(tmpDir, cfg)
+
+	root := matcher.Rule("")
+	if root.MaxDepth != 0 || root.Files || !root.Hidden || root.MaxChildren != 10 {
+		t.Errorf("unexpected root rule: %+v", root)
+	}
+
+	nested := matcher.Rule("internal/gen/pb")
+	if nested.MaxDepth != 3 || nested.DepthBase != "internal" {
+		t.Errorf("expected max_depth inherited from internal, got %+v", nested)
+	}
+	if !nested.Files || nested.Hidden || nested.MaxChildren != 2 {
+		t.Errorf("expected overrides to compose, got %+v", nested)
+	}
+
+	if !matcher.Rule("web").Collapse {
+		t.Error("expected web to be collapsed")
+	}
+	if matcher.Rule("web/src").Collapse {
+		t.Error("expected collapse not to apply below web")
+	}
+}
+
+func TestMatcher_FullRelativePaths(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{
+		Structure: config.StructureConfig{
+			Patterns: []string{"/build/", "docs/generated/"},
+		},
+	}
+	matcher := NewMatcher(tmpDir, cfg)
+
+	if !matcher.IsExcluded("build", true) {
+		t.Error("expected root build to be excluded")
+	}
+	if matcher.IsExcluded("tools/build", true) {
+		t.Error("expected anchored pattern not to exclude tools/build")
+	}
+	if !matcher.IsExcluded("docs/generated", true) {
+		t.Error("expected docs/generated to be excluded")
+	}
+}
+
+func TestScanAuto_PathOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for _, d := range []string{"internal/a/b/c/d", "web/src/components", ".github/workflows", "internal/.cache"} {
+		if err := os.MkdirAll(filepath.Join(tmpDir, d), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "internal", "doc.go"), []byte("package internal"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	configContent := `structure:
+  max_depth: 1
+  paths:
+    internal:
+      max_depth: 3
+      files: true
+      hidden: false
+    web:
+      collapse: true
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".readme-gen.yaml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	result, err := ScanAuto(tmpDir)
+	if err != nil {
+		t.Fatalf("ScanAuto failed: %v", err)
+	}
+
+	expected := `├── .github/
+│   └── workflows/
+├── internal/
+│   ├── a/
+│   │   └── b/
+│   │       └── c/
+│   └── doc.go
+└── web/`
+	if result != expected {
+		t.Errorf("unexpected tree:\n%s\nwant:\n%s", result, expected)
+	}
+}
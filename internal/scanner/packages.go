@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Package is a nested package of a monorepo
+type Package struct {
+	// Path is the slash-separated path relative to the project root
+	Path        string
+	Name        string
+	Description string
+	Language    string
+}
+
+// skipPackageDirs are never searched for packages
+var skipPackageDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"testdata":     true,
+}
+
+// packageDepth is how deep below the root packages are looked for
+// (e.g. "packages/core" or "services/api")
+const packageDepth = 2
+
+// DetectPackages returns the directories below root, up to two levels deep,
+// that have their own manifest. Hidden and ignored directories are skipped.
+func DetectPackages(root string) []Package {
+	matcher := DefaultMatcher(root)
+
+	var packages []Package
+	var walk func(dir, relPath string, depth int)
+	walk = func(dir, relPath string, depth int) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			childRel := joinRelPath(relPath, name)
+			if !entry.IsDir() || strings.HasPrefix(name, ".") || skipPackageDirs[name] || matcher.IsExcluded(childRel, true) {
+				continue
+			}
+
+			childDir := filepath.Join(dir, name)
+			if manifests := detectManifests(childDir); len(manifests) > 0 {
+				info := projectInfo(childDir, manifests, nil)
+				packages = append(packages, Package{
+					Path:        childRel,
+					Name:        info.Name,
+					Description: info.Description,
+					Language:    info.Language,
+				})
+				continue
+			}
+			if depth < packageDepth {
+				walk(childDir, childRel, depth+1)
+			}
+		}
+	}
+	walk(root, "", 1)
+
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Path < packages[j].Path })
+	return packages
+}
@@ -0,0 +1,48 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectPackages(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":                        "module example.com/mono\n",
+		"packages/core/package.json":    `{"name": "@mono/core", "description": "Core logic"}`,
+		"packages/core/tsconfig.json":   "{}",
+		"packages/ui/package.json":      `{"name": "@mono/ui"}`,
+		"services/api/go.mod":           "module example.com/mono/services/api\n",
+		"tools/gen/deep/go.mod":         "module example.com/too/deep\n",
+		"node_modules/dep/package.json": `{"name": "dep"}`,
+		".cache/pkg/package.json":       `{"name": "hidden"}`,
+		"docs/README.md":                "# Docs\n",
+		"crates/parser/Cargo.toml":      "[package]\nname = \"parser\"\n",
+	}
+	for path, content := range files {
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := DetectPackages(root)
+	want := []Package{
+		{Path: "crates/parser", Name: "parser", Language: "rust"},
+		{Path: "packages/core", Name: "@mono/core", Description: "Core logic", Language: "typescript"},
+		{Path: "packages/ui", Name: "@mono/ui", Language: "javascript"},
+		{Path: "services/api", Name: "api", Language: "go"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("DetectPackages() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("DetectPackages()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package scanner
 
 import (
-	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/hulk510/readme-gen/internal/config"
 )
 
 // ProjectInfo contains detected project metadata
@@ -14,6 +16,34 @@ type ProjectInfo struct {
 	Description string
 	Language    string
 	ModulePath  string
+	// Version is the version declared in the primary manifest, if any
+	Version string
+	// Ecosystem is the package ecosystem of the primary manifest (go, npm, cargo, ...)
+	Ecosystem string
+	// Languages lists the languages of the source files, most files first.
+	// Language is the first of them.
+	Languages []string
+	// Ecosystems lists every detected manifest, primary first
+	Ecosystems []Manifest
+	// License is the SPDX identifier detected from the license file (e.g. "Apache-2.0")
+	License string
+	// LicenseFile is the name of the license file (e.g. "LICENSE")
+	LicenseFile string
+	// RepoURL is the web URL of the origin remote (e.g. "https://github.com/owner/repo")
+	RepoURL string
+	// DefaultBranch is the default branch of the repository (e.g. "main")
+	DefaultBranch string
+	// LatestTag is the highest version tag of the local repository (e.g. "v1.2.0")
+	LatestTag string
+	// GoVersion and NodeVersion are the toolchain versions the project requires
+	GoVersion   string
+	NodeVersion string
+	// Tasks lists the development commands defined in task runner files
+	Tasks []Task
+	// Binaries lists the main packages of the Go module, if any
+	Binaries []Binary
+	// Packages lists nested packages with their own manifest (monorepos)
+	Packages []Package
 }
 
 // DefaultExcludes returns the default list of directories to exclude
@@ -38,42 +68,26 @@ func DefaultExcludes() []string {
 
 // DetectProjectInfo detects project metadata from common files
 func DetectProjectInfo(root string) ProjectInfo {
-	info := ProjectInfo{
-		Name: filepath.Base(absPath(root)),
-	}
-
-	// Try go.mod
-	if content, err := os.ReadFile(filepath.Join(root, "go.mod")); err == nil {
-		info.Language = "go"
-		lines := strings.Split(string(content), "\n")
-		for _, line := range lines {
-			if strings.HasPrefix(line, "module ") {
-				info.ModulePath = strings.TrimPrefix(line, "module ")
-				// Extract name from module path
-				parts := strings.Split(info.ModulePath, "/")
-				if len(parts) > 0 {
-					info.Name = parts[len(parts)-1]
-				}
-				break
-			}
+	info := projectInfo(root, detectManifests(root), rankLanguages(root))
+	info.Packages = DetectPackages(root)
+	info.License, info.LicenseFile = detectLicense(root)
+	info.GoVersion = detectGoVersion(root)
+	info.NodeVersion = detectNodeVersion(root)
+	info.Tasks = DetectTasks(root)
+	for _, m := range info.Ecosystems {
+		if m.Ecosystem == EcosystemGo && m.ModulePath != "" {
+			info.Binaries = DetectBinaries(root, m.ModulePath)
 		}
 	}
 
-	// Try package.json
-	if content, err := os.ReadFile(filepath.Join(root, "package.json")); err == nil {
-		info.Language = "typescript"
-		var pkg struct {
-			Name        string `json:"name"`
-			Description string `json:"description"`
-		}
-		if json.Unmarshal(content, &pkg) == nil {
-			if pkg.Name != "" {
-				info.Name = pkg.Name
-			}
-			info.Description = pkg.Description
-		}
+	git := detectGit(root)
+	info.RepoURL = git.RepoURL
+	info.DefaultBranch = git.DefaultBranch
+	info.LatestTag = git.LatestTag
+	// Fall back to the release tag when no manifest declares a version
+	if info.Version == "" {
+		info.Version = strings.TrimPrefix(info.LatestTag, "v")
 	}
-
 	return info
 }
 
@@ -87,7 +101,7 @@ func Scan(root string, excludes []string) (string, error) {
 // ScanWithMatcher scans the directory using the provided matcher
 func ScanWithMatcher(root string, matcher *Matcher) (string, error) {
 	var builder strings.Builder
-	err := walkDirWithMatcher(root, "", &builder, matcher, true, 0)
+	err := walkDirWithMatcher(root, "", "", &builder, matcher, 0)
 	if err != nil {
 		return "", err
 	}
@@ -95,6 +109,44 @@ func ScanWithMatcher(root string, matcher *Matcher) (string, error) {
 	return strings.TrimSuffix(builder.String(), "\n"), nil
 }
 
+// ScanPath scans the subdirectory relPath of root with the same rules as
+// ScanWithMatcher and renders it as a tree rooted at "relPath/". levels
+// limits the number of levels shown below relPath (0 = no additional limit).
+func ScanPath(root, relPath string, matcher *Matcher, levels int) (string, error) {
+	relPath = config.NormalizePath(relPath)
+
+	var builder strings.Builder
+	if relPath == "" {
+		if err := walkDirWithMatcher(root, "", "", &builder, matcher, 0); err != nil {
+			return "", err
+		}
+	} else {
+		builder.WriteString(relPath + "/\n")
+		dir := filepath.Join(root, filepath.FromSlash(relPath))
+		if err := walkDirWithMatcher(dir, relPath, "", &builder, matcher, pathDepth(relPath)+1); err != nil {
+			return "", err
+		}
+	}
+
+	return limitLevels(strings.TrimSuffix(builder.String(), "\n"), levels), nil
+}
+
+// limitLevels drops tree lines nested levels or more below the top
+func limitLevels(structure string, levels int) string {
+	if levels <= 0 {
+		return structure
+	}
+
+	var kept []string
+	for _, line := range strings.Split(structure, "\n") {
+		prefix := strings.IndexAny(line, "├└")
+		if prefix == -1 || utf8.RuneCountInString(line[:prefix])/4 < levels {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
 // ScanAuto scans the directory using auto-loaded configuration
 func ScanAuto(root string) (string, error) {
 	matcher, err := LoadMatcher(root)
@@ -104,9 +156,17 @@ func ScanAuto(root string) (string, error) {
 	return ScanWithMatcher(root, matcher)
 }
 
-func walkDirWithMatcher(path string, prefix string, builder *strings.Builder, matcher *Matcher, isRoot bool, depth int) error {
-	// Check max depth
-	if matcher.MaxDepth() > 0 && depth > matcher.MaxDepth() {
+// walkDirWithMatcher writes the tree for the directory at path.
+// relPath is the slash-separated path relative to the scan root ("" for the root).
+func walkDirWithMatcher(path string, relPath string, prefix string, builder *strings.Builder, matcher *Matcher, depth int) error {
+	rule := matcher.Rule(relPath)
+
+	// Check max depth (per-path depth is counted from the path it was set on)
+	if rule.MaxDepth > 0 {
+		if depth-pathDepth(rule.DepthBase) > rule.MaxDepth {
+			return nil
+		}
+	} else if matcher.MaxDepth() > 0 && depth > matcher.MaxDepth() {
 		return nil
 	}
 
@@ -116,46 +176,38 @@ func walkDirWithMatcher(path string, prefix string, builder *strings.Builder, ma
 	}
 
 	// Filter and sort entries
-	var dirs []os.DirEntry
+	var children []os.DirEntry
 	for _, entry := range entries {
-		if !entry.IsDir() {
+		if !entry.IsDir() && !rule.Files {
 			continue
 		}
 
 		name := entry.Name()
+		childRel := joinRelPath(relPath, name)
 
-		// Skip hidden files (except at root level or if explicitly included)
-		if strings.HasPrefix(name, ".") && !isRoot {
-			// Calculate relative path for matcher
-			relPath := name
-			if prefix != "" {
-				// Reconstruct relative path from prefix
-				relPath = getRelPath(prefix, name)
-			}
-			if matcher.IsExcluded(relPath, true) {
-				continue
-			}
-		} else {
-			// Calculate relative path
-			relPath := name
-			if prefix != "" {
-				relPath = getRelPath(prefix, name)
-			}
-			if matcher.IsExcluded(relPath, true) {
-				continue
-			}
+		if strings.HasPrefix(name, ".") && !rule.Hidden && !matcher.IsIncluded(childRel) {
+			continue
+		}
+
+		if matcher.IsExcluded(childRel, entry.IsDir()) {
+			continue
 		}
 
-		dirs = append(dirs, entry)
+		children = append(children, entry)
 	}
 
-	sort.Slice(dirs, func(i, j int) bool {
-		return dirs[i].Name() < dirs[j].Name()
-	})
+	matcher.sortEntries(relPath, children)
 
-	for i, entry := range dirs {
+	// Limit the number of rendered children
+	elided := 0
+	if rule.MaxChildren > 0 && len(children) > rule.MaxChildren {
+		elided = len(children) - rule.MaxChildren
+		children = children[:rule.MaxChildren]
+	}
+
+	for i, entry := range children {
 		name := entry.Name()
-		isLast := i == len(dirs)-1
+		isLast := i == len(children)-1 && elided == 0
 
 		// Determine the connector
 		connector := "├── "
@@ -163,9 +215,19 @@ func walkDirWithMatcher(path string, prefix string, builder *strings.Builder, ma
 			connector = "└── "
 		}
 
+		if !entry.IsDir() {
+			builder.WriteString(prefix + connector + name + "\n")
+			continue
+		}
+
 		// Write the entry
 		builder.WriteString(prefix + connector + name + "/\n")
 
+		childRel := joinRelPath(relPath, name)
+		if matcher.Rule(childRel).Collapse {
+			continue
+		}
+
 		// Recurse into subdirectory
 		newPrefix := prefix
 		if isLast {
@@ -175,24 +237,37 @@ func walkDirWithMatcher(path string, prefix string, builder *strings.Builder, ma
 		}
 
 		subPath := filepath.Join(path, name)
-		if err := walkDirWithMatcher(subPath, newPrefix, builder, matcher, false, depth+1); err != nil {
+		if err := walkDirWithMatcher(subPath, childRel, newPrefix, builder, matcher, depth+1); err != nil {
 			return err
 		}
 	}
 
+	if elided > 0 {
+		builder.WriteString(prefix + "└── " + ElisionLine(elided) + "\n")
+	}
+
 	return nil
 }
 
-// getRelPath reconstructs the relative path from tree prefix and name
-func getRelPath(prefix, name string) string {
-	// Count depth from prefix (each level adds 4 chars: "│   " or "    ")
-	depth := len(prefix) / 4
-	if depth == 0 {
+// pathDepth returns the depth of a relative path's entries (root = -1, "a" = 0, "a/b" = 1)
+func pathDepth(relPath string) int {
+	if relPath == "" {
+		return -1
+	}
+	return strings.Count(relPath, "/")
+}
+
+// ElisionLine returns the text rendered in place of children hidden by max_children
+func ElisionLine(count int) string {
+	return fmt.Sprintf("… (%d more)", count)
+}
+
+// joinRelPath appends name to a slash-separated relative path
+func joinRelPath(relPath, name string) string {
+	if relPath == "" {
 		return name
 	}
-	// We can't reconstruct the full path from prefix alone,
-	// so we just return the name for matching
-	return name
+	return relPath + "/" + name
 }
 
 func absPath(path string) string {
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/hulk510/readme-gen/internal/config"
 )
 
 func TestDefaultExcludes(t *testing.T) {
@@ -98,6 +100,9 @@ func TestDetectProjectInfo_PackageJson(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to write package.json: %v", err)
 	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "tsconfig.json"), []byte("{}"), 0644); err != nil {
+		t.Fatalf("failed to write tsconfig.json: %v", err)
+	}
 
 	info := DetectProjectInfo(tmpDir)
 
@@ -140,3 +145,75 @@ func TestScan_TreeFormat(t *testing.T) {
 		t.Error("expected tree format with ├── or └──")
 	}
 }
+
+func TestScanWithMatcher_MaxChildren(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for _, d := range []string{"a", "b", "c", "d", "e", "db/migrations/001", "db/migrations/002", "db/migrations/003"} {
+		if err := os.MkdirAll(filepath.Join(tmpDir, d), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+
+	cfg := config.Default()
+	cfg.Structure.MaxChildren = 4
+	cfg.Structure.Paths = map[string]config.PathConfig{
+		"db/migrations": {MaxChildren: 1},
+	}
+
+	result, err := ScanWithMatcher(tmpDir, NewMatcher(tmpDir, cfg))
+	if err != nil {
+		t.Fatalf("ScanWithMatcher failed: %v", err)
+	}
+
+	expected := `├── a/
+├── b/
+├── c/
+├── d/
+└── … (2 more)`
+	if result != expected {
+		t.Errorf("unexpected tree:\n%s\nwant:\n%s", result, expected)
+	}
+
+	cfg.Structure.MaxChildren = 0
+	result, err = ScanWithMatcher(tmpDir, NewMatcher(tmpDir, cfg))
+	if err != nil {
+		t.Fatalf("ScanWithMatcher failed: %v", err)
+	}
+	if !strings.Contains(result, "│   └── migrations/\n│       ├── 001/\n│       └── … (2 more)") {
+		t.Errorf("expected per-path limit on db/migrations, got:\n%s", result)
+	}
+}
+
+func TestScanWithMatcher_WorkDir(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".readme-gen/backups/20240101-000000"), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	result, err := ScanWithMatcher(tmpDir, NewMatcher(tmpDir, config.Default()))
+	if err != nil {
+		t.Fatalf("ScanWithMatcher failed: %v", err)
+	}
+	if result != "" {
+		t.Errorf("expected a work directory with only backups to be excluded, got:\n%s", result)
+	}
+
+	for _, d := range []string{".readme-gen/templates/partials", ".readme-gen/backups/20240101-000000"} {
+		if err := os.MkdirAll(filepath.Join(tmpDir, d), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+
+	result, err = ScanWithMatcher(tmpDir, NewMatcher(tmpDir, config.Default()))
+	if err != nil {
+		t.Fatalf("ScanWithMatcher failed: %v", err)
+	}
+
+	if !strings.Contains(result, ".readme-gen/") || !strings.Contains(result, "templates/") {
+		t.Errorf("expected .readme-gen/templates in tree, got:\n%s", result)
+	}
+	if strings.Contains(result, "backups/") {
+		t.Errorf("expected .readme-gen/backups to be excluded, got:\n%s", result)
+	}
+}
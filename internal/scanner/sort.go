@@ -0,0 +1,116 @@
+package scanner
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hulk510/readme-gen/internal/config"
+)
+
+// sortEntries orders the children of the directory at relPath according
+// to the matcher's sort settings. Pinned paths come first in pin order,
+// then directories (if dirs-first is enabled), then everything else.
+func (m *Matcher) sortEntries(relPath string, entries []os.DirEntry) {
+	less := lessFunc(m.sortMode)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+
+		pa, aPinned := m.pins[joinRelPath(relPath, a.Name())]
+		pb, bPinned := m.pins[joinRelPath(relPath, b.Name())]
+		if aPinned || bPinned {
+			if aPinned && bPinned {
+				return pa < pb
+			}
+			return aPinned
+		}
+
+		if m.dirsFirst && a.IsDir() != b.IsDir() {
+			return a.IsDir()
+		}
+
+		return less(a.Name(), b.Name())
+	})
+}
+
+// lessFunc returns the name comparison for a sort mode
+func lessFunc(mode string) func(a, b string) bool {
+	switch mode {
+	case config.SortCaseInsensitive:
+		return func(a, b string) bool {
+			la, lb := strings.ToLower(a), strings.ToLower(b)
+			if la != lb {
+				return la < lb
+			}
+			return a < b
+		}
+	case config.SortNatural:
+		return func(a, b string) bool {
+			if c := naturalCompare(a, b); c != 0 {
+				return c < 0
+			}
+			return a < b
+		}
+	default:
+		return func(a, b string) bool {
+			return a < b
+		}
+	}
+}
+
+// naturalCompare compares names case-insensitively, treating runs of
+// digits as numbers so that "v2" sorts before "v10"
+func naturalCompare(a, b string) int {
+	ra, rb := []rune(strings.ToLower(a)), []rune(strings.ToLower(b))
+	i, j := 0, 0
+
+	for i < len(ra) && j < len(rb) {
+		if unicode.IsDigit(ra[i]) && unicode.IsDigit(rb[j]) {
+			si := i
+			for i < len(ra) && unicode.IsDigit(ra[i]) {
+				i++
+			}
+			sj := j
+			for j < len(rb) && unicode.IsDigit(rb[j]) {
+				j++
+			}
+			if c := compareNumbers(string(ra[si:i]), string(rb[sj:j])); c != 0 {
+				return c
+			}
+			continue
+		}
+
+		if ra[i] != rb[j] {
+			if ra[i] < rb[j] {
+				return -1
+			}
+			return 1
+		}
+		i++
+		j++
+	}
+
+	switch {
+	case len(ra)-i < len(rb)-j:
+		return -1
+	case len(ra)-i > len(rb)-j:
+		return 1
+	}
+	return 0
+}
+
+// compareNumbers compares two digit strings by numeric value without
+// overflowing on long runs
+func compareNumbers(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,105 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hulk510/readme-gen/internal/config"
+)
+
+func TestNaturalCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v2", "v10", -1},
+		{"v10", "v2", 1},
+		{"file007", "file7", 0},
+		{"Alpha", "beta", -1},
+		{"2024_01", "2024_1a", -1},
+		{"abc", "abc", 0},
+		{"ab", "abc", -1},
+	}
+
+	for _, tt := range tests {
+		got := naturalCompare(tt.a, tt.b)
+		if got != tt.want {
+			t.Errorf("naturalCompare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestScanWithMatcher_SortModes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for _, d := range []string{"Zeta", "alpha", "v10", "v2", "cmd", "internal"} {
+		if err := os.MkdirAll(filepath.Join(tmpDir, d), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "README.md"), []byte("# test"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		sort      string
+		dirsFirst bool
+		files     bool
+		pin       []string
+		want      []string
+	}{
+		{
+			name: "byte order",
+			want: []string{"Zeta/", "alpha/", "cmd/", "internal/", "v10/", "v2/"},
+		},
+		{
+			name: "case-insensitive",
+			sort: config.SortCaseInsensitive,
+			want: []string{"alpha/", "cmd/", "internal/", "v10/", "v2/", "Zeta/"},
+		},
+		{
+			name: "natural",
+			sort: config.SortNatural,
+			want: []string{"alpha/", "cmd/", "internal/", "v2/", "v10/", "Zeta/"},
+		},
+		{
+			name:      "dirs first with files",
+			sort:      config.SortNatural,
+			dirsFirst: true,
+			files:     true,
+			want:      []string{"alpha/", "cmd/", "internal/", "v2/", "v10/", "Zeta/", "README.md"},
+		},
+		{
+			name: "pinned",
+			sort: config.SortNatural,
+			pin:  []string{"internal/", "cmd"},
+			want: []string{"internal/", "cmd/", "alpha/", "v2/", "v10/", "Zeta/"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.Structure.Sort = tt.sort
+			cfg.Structure.DirsFirst = tt.dirsFirst
+			cfg.Structure.Files = tt.files
+			cfg.Structure.Pin = tt.pin
+
+			result, err := ScanWithMatcher(tmpDir, NewMatcher(tmpDir, cfg))
+			if err != nil {
+				t.Fatalf("ScanWithMatcher failed: %v", err)
+			}
+
+			var got []string
+			for _, line := range strings.Split(result, "\n") {
+				got = append(got, strings.TrimLeft(line, "├└─ "))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
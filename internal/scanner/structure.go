@@ -0,0 +1,36 @@
+package scanner
+
+import (
+	"github.com/hulk510/readme-gen/internal/config"
+	"github.com/hulk510/readme-gen/internal/marker"
+)
+
+// Structure scans relPath ("" for the whole project) under root with the
+// project configuration and annotates directories with comments from the
+// configured description sources and the descriptions map for lang.
+// levels limits the depth below relPath (0 = configured depth).
+func Structure(root, relPath string, cfg *config.Config, lang string, levels int) (string, error) {
+	structure, err := ScanPath(root, relPath, NewMatcher(root, cfg), levels)
+	if err != nil {
+		return "", err
+	}
+
+	comments := make(map[string]string)
+	if sources := cfg.Structure.DescriptionSources; len(sources) > 0 {
+		var dirs []string
+		for _, e := range marker.ParseTree(structure) {
+			if e.IsDir {
+				dirs = append(dirs, e.Path)
+			}
+		}
+		comments = DescribePaths(root, dirs, sources)
+	}
+	for p, text := range cfg.DescriptionsFor(lang) {
+		comments[p] = text
+	}
+
+	if len(comments) == 0 {
+		return structure, nil
+	}
+	return marker.Annotate(structure, comments, cfg.Structure.CommentWidth), nil
+}
@@ -0,0 +1,347 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Task runners that tasks are detected from
+const (
+	RunnerMise = "mise"
+	RunnerMake = "make"
+	RunnerTask = "task"
+	RunnerJust = "just"
+	RunnerNpm  = "npm"
+)
+
+// Task is a development command defined in a task runner file
+type Task struct {
+	// Runner is the tool that runs the task (mise, make, task, just, npm)
+	Runner string
+	// Name is the name of the task
+	Name string
+	// Command is the shell command that runs the task (e.g. "mise run build")
+	Command string
+	// Description is the help text of the task, if any
+	Description string
+}
+
+// taskDetector returns the tasks defined in dir for one runner
+type taskDetector func(dir string) []Task
+
+// taskDetectors are tried in order; the tasks of every runner are returned
+var taskDetectors = []taskDetector{
+	detectMiseTasks,
+	detectMakeTasks,
+	detectTaskfileTasks,
+	detectJustTasks,
+	detectPackageScripts,
+}
+
+// DetectTasks returns the tasks defined by the task runners in root, in the
+// order they are declared
+func DetectTasks(root string) []Task {
+	var tasks []Task
+	for _, detect := range taskDetectors {
+		tasks = append(tasks, detect(root)...)
+	}
+	return tasks
+}
+
+// miseTaskHeader matches the table header of a mise task ([tasks.build] or [tasks."build:all"])
+var miseTaskHeader = regexp.MustCompile(`^\[tasks\.("[^"]+"|'[^']+'|[A-Za-z0-9_:-]+)\]$`)
+
+// detectMiseTasks reads the tasks of mise.toml, both [tasks.<name>] tables
+// and entries of the [tasks] table. Hidden tasks are skipped.
+func detectMiseTasks(dir string) []Task {
+	_, content, ok := readFirst(dir, miseFiles...)
+	if !ok {
+		return nil
+	}
+
+	var tasks []Task
+	current := -1
+	hidden := make(map[int]bool)
+	inTasksTable := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			current = -1
+			inTasksTable = line == "[tasks]"
+			if m := miseTaskHeader.FindStringSubmatch(line); m != nil {
+				tasks = append(tasks, miseTask(unquoteValue(m[1])))
+				current = len(tasks) - 1
+			}
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch {
+		case current >= 0 && key == "description":
+			tasks[current].Description = unquoteValue(value)
+		case current >= 0 && key == "hide":
+			hidden[current] = value == "true"
+		case inTasksTable:
+			// build = "go build" or build = { run = "...", description = "..." }
+			task := miseTask(unquoteValue(key))
+			if strings.HasPrefix(value, "{") {
+				fields := inlineTable(value)
+				task.Description = fields["description"]
+				if fields["hide"] == "true" {
+					continue
+				}
+			}
+			tasks = append(tasks, task)
+		}
+	}
+
+	visible := tasks[:0]
+	for i, task := range tasks {
+		if !hidden[i] {
+			visible = append(visible, task)
+		}
+	}
+	return visible
+}
+
+// miseTask returns the task that runs the mise task name
+func miseTask(name string) Task {
+	return Task{Runner: RunnerMise, Name: name, Command: "mise run " + name}
+}
+
+// inlineTableField matches `key = value` pairs of a TOML inline table
+var inlineTableField = regexp.MustCompile(`([A-Za-z0-9_-]+)\s*=\s*("[^"]*"|'[^']*'|[^,}]+)`)
+
+// inlineTable returns the single-line values of a TOML inline table
+func inlineTable(value string) map[string]string {
+	fields := make(map[string]string)
+	for _, m := range inlineTableField.FindAllStringSubmatch(value, -1) {
+		fields[m[1]] = unquoteValue(strings.TrimSpace(m[2]))
+	}
+	return fields
+}
+
+// makeHelpTarget matches Makefile targets documented with a help comment
+// (build: deps ## Build the binary)
+var makeHelpTarget = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9_./-]*)\s*:[^=#]*##\s*(.*)$`)
+
+// detectMakeTasks reads the Makefile targets that have a `##` help comment
+func detectMakeTasks(dir string) []Task {
+	_, content, ok := readFirst(dir, "GNUmakefile", "Makefile", "makefile")
+	if !ok {
+		return nil
+	}
+
+	var tasks []Task
+	for _, line := range strings.Split(content, "\n") {
+		if m := makeHelpTarget.FindStringSubmatch(strings.TrimRight(line, "\r")); m != nil {
+			tasks = append(tasks, Task{Runner: RunnerMake, Name: m[1], Command: "make " + m[1], Description: strings.TrimSpace(m[2])})
+		}
+	}
+	return tasks
+}
+
+// detectTaskfileTasks reads the tasks of a Taskfile. Internal tasks are skipped.
+func detectTaskfileTasks(dir string) []Task {
+	_, content, ok := readFirst(dir, "Taskfile.yml", "Taskfile.yaml", "taskfile.yml", "taskfile.yaml")
+	if !ok {
+		return nil
+	}
+
+	var taskfile struct {
+		Tasks yaml.Node `yaml:"tasks"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &taskfile); err != nil || taskfile.Tasks.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var tasks []Task
+	nodes := taskfile.Tasks.Content
+	for i := 0; i+1 < len(nodes); i += 2 {
+		name := nodes[i].Value
+		var def struct {
+			Desc     string `yaml:"desc"`
+			Internal bool   `yaml:"internal"`
+		}
+		// Tasks may also be a command string or a list of commands
+		if nodes[i+1].Kind == yaml.MappingNode {
+			_ = nodes[i+1].Decode(&def)
+		}
+		if def.Internal {
+			continue
+		}
+		tasks = append(tasks, Task{Runner: RunnerTask, Name: name, Command: "task " + name, Description: def.Desc})
+	}
+	return tasks
+}
+
+var (
+	// justRecipe matches a recipe header: name, parameters and dependencies
+	// (build target="debug": deps). Assignments (x := y) are excluded later.
+	justRecipe = regexp.MustCompile(`^@?([A-Za-z_][A-Za-z0-9_-]*)([^:]*):`)
+	// justDoc matches the [doc("...")] attribute
+	justDoc = regexp.MustCompile(`^\[doc\(\s*["'](.*)["']\s*\)\]$`)
+)
+
+// detectJustTasks reads the recipes of a justfile, taking descriptions from
+// the comment or [doc] attribute above them. Private recipes are skipped.
+func detectJustTasks(dir string) []Task {
+	_, content, ok := readFirst(dir, "justfile", "Justfile", ".justfile")
+	if !ok {
+		return nil
+	}
+
+	var tasks []Task
+	comment, doc := "", ""
+	private := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			comment, doc, private = "", "", false
+			continue
+		case line[0] == ' ' || line[0] == '\t':
+			// Recipe body
+			continue
+		case strings.HasPrefix(trimmed, "#"):
+			if !strings.HasPrefix(trimmed, "#!") {
+				comment = strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))
+			}
+			continue
+		case strings.HasPrefix(trimmed, "["):
+			if m := justDoc.FindStringSubmatch(trimmed); m != nil {
+				doc = m[1]
+			}
+			if strings.Contains(trimmed, "private") {
+				private = true
+			}
+			continue
+		}
+
+		m := justRecipe.FindStringSubmatch(line)
+		if m == nil || isJustAssignment(line, m) || isJustKeyword(m[1]) || strings.HasPrefix(m[1], "_") || private {
+			comment, doc, private = "", "", false
+			continue
+		}
+
+		command := "just " + m[1]
+		for _, param := range strings.Fields(m[2]) {
+			// Parameters with a default and variadic ones can be omitted
+			if strings.Contains(param, "=") || strings.HasPrefix(param, "*") {
+				continue
+			}
+			command += " <" + strings.TrimLeft(param, "+$") + ">"
+		}
+		description := comment
+		if doc != "" {
+			description = doc
+		}
+		tasks = append(tasks, Task{Runner: RunnerJust, Name: m[1], Command: command, Description: description})
+		comment, doc, private = "", "", false
+	}
+	return tasks
+}
+
+// isJustAssignment reports whether a line matched by justRecipe is a
+// variable assignment (name := value or name = "a:b")
+func isJustAssignment(line string, m []string) bool {
+	return strings.HasPrefix(line[len(m[0]):], "=") || strings.HasPrefix(strings.TrimSpace(m[2]), "=")
+}
+
+// isJustKeyword reports whether name starts a justfile statement rather than a recipe
+func isJustKeyword(name string) bool {
+	switch name {
+	case "set", "alias", "export", "import", "mod":
+		return true
+	}
+	return false
+}
+
+// detectPackageScripts reads the scripts of package.json, run with the
+// package manager of the lockfile. Lifecycle hooks (pre*/post*) are skipped.
+func detectPackageScripts(dir string) []Task {
+	content, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil
+	}
+	var pkg struct {
+		Scripts json.RawMessage `json:"scripts"`
+	}
+	if json.Unmarshal(content, &pkg) != nil || len(pkg.Scripts) == 0 {
+		return nil
+	}
+	names := objectKeys(pkg.Scripts)
+
+	run := packageRunner(dir)
+	defined := make(map[string]bool, len(names))
+	for _, name := range names {
+		defined[name] = true
+	}
+
+	var tasks []Task
+	for _, name := range names {
+		if hook, ok := strings.CutPrefix(name, "pre"); ok && defined[hook] {
+			continue
+		}
+		if hook, ok := strings.CutPrefix(name, "post"); ok && defined[hook] {
+			continue
+		}
+		tasks = append(tasks, Task{Runner: RunnerNpm, Name: name, Command: run + " " + name})
+	}
+	return tasks
+}
+
+// packageRunner returns the command that runs package.json scripts, chosen
+// by the lockfile in dir
+func packageRunner(dir string) string {
+	for _, lock := range []struct{ file, run string }{
+		{"bun.lock", "bun run"},
+		{"bun.lockb", "bun run"},
+		{"pnpm-lock.yaml", "pnpm"},
+		{"yarn.lock", "yarn"},
+	} {
+		if _, err := os.Stat(filepath.Join(dir, lock.file)); err == nil {
+			return lock.run
+		}
+	}
+	return "npm run"
+}
+
+// objectKeys returns the keys of a JSON object in the order they appear
+func objectKeys(object json.RawMessage) []string {
+	dec := json.NewDecoder(bytes.NewReader(object))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil
+	}
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return keys
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return keys
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return keys
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,103 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectTasks(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"mise.toml": `[tools]
+go = "1.25"
+
+[tasks]
+fmt = "go fmt ./..."
+lint = { run = "go vet ./...", description = "Run linter" }
+
+[tasks.build]
+description = "Build the binary"
+run = "go build"
+
+[tasks."release:dry"]
+description = 'Dry-run a release'
+
+[tasks.internal]
+hide = true
+`,
+		"Makefile": `.PHONY: test
+test: deps ## Run tests
+	go test ./...
+
+deps:
+	go mod download
+
+docker/build: ## Build the image
+	docker build .
+`,
+		"Taskfile.yml": `version: '3'
+tasks:
+  serve:
+    desc: Start the server
+    cmds: [go run .]
+  gen: go generate ./...
+  setup:
+    internal: true
+`,
+		"justfile": `set dotenv-load
+version := "1.0"
+
+# Deploy to an environment
+deploy env region="us":
+    ./deploy.sh {{env}}
+
+[doc("Open a shell")]
+@shell:
+    bash
+
+_helper:
+    true
+
+[private]
+secret:
+    true
+`,
+		"package.json":   `{"scripts": {"prebuild": "rm -rf dist", "build": "tsc", "dev": "vite", "postinstall": "husky"}}`,
+		"pnpm-lock.yaml": "",
+	})
+
+	var got [][3]string
+	for _, task := range DetectTasks(root) {
+		got = append(got, [3]string{task.Runner, task.Command, task.Description})
+	}
+	want := [][3]string{
+		{RunnerMise, "mise run fmt", ""},
+		{RunnerMise, "mise run lint", "Run linter"},
+		{RunnerMise, "mise run build", "Build the binary"},
+		{RunnerMise, "mise run release:dry", "Dry-run a release"},
+		{RunnerMake, "make test", "Run tests"},
+		{RunnerMake, "make docker/build", "Build the image"},
+		{RunnerTask, "task serve", "Start the server"},
+		{RunnerTask, "task gen", ""},
+		{RunnerJust, "just deploy <env>", "Deploy to an environment"},
+		{RunnerJust, "just shell", "Open a shell"},
+		{RunnerNpm, "pnpm build", ""},
+		{RunnerNpm, "pnpm dev", ""},
+		{RunnerNpm, "pnpm postinstall", ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectTasks() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestDetectTasks_None(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"Makefile":     "build:\n\tgo build\n",
+		"package.json": `{"name": "app"}`,
+	})
+
+	if tasks := DetectTasks(root); len(tasks) != 0 {
+		t.Errorf("expected no tasks, got %+v", tasks)
+	}
+}
@@ -0,0 +1,61 @@
+package scanner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// miseFiles are the mise configuration files checked for tool versions
+var miseFiles = []string{"mise.toml", ".mise.toml"}
+
+// versionNumber extracts the version from constraints such as ">=18.0.0", "^20" or "20.x"
+var versionNumber = regexp.MustCompile(`\d+(?:\.\d+)*`)
+
+// detectGoVersion returns the Go version required by go.mod, falling back to
+// the version pinned in mise.toml
+func detectGoVersion(root string) string {
+	if content, err := os.ReadFile(filepath.Join(root, "go.mod")); err == nil {
+		for _, line := range strings.Split(string(content), "\n") {
+			if version, ok := strings.CutPrefix(strings.TrimSpace(line), "go "); ok {
+				return strings.TrimSpace(version)
+			}
+		}
+	}
+	return miseToolVersion(root, "go")
+}
+
+// detectNodeVersion returns the Node.js version from engines.node in
+// package.json, falling back to the version pinned in mise.toml
+func detectNodeVersion(root string) string {
+	if content, err := os.ReadFile(filepath.Join(root, "package.json")); err == nil {
+		var pkg struct {
+			Engines struct {
+				Node string `json:"node"`
+			} `json:"engines"`
+		}
+		if json.Unmarshal(content, &pkg) == nil {
+			if version := versionNumber.FindString(pkg.Engines.Node); version != "" {
+				return version
+			}
+		}
+	}
+	return miseToolVersion(root, "node")
+}
+
+// miseToolVersion returns the version of tool in the [tools] table of mise.toml
+func miseToolVersion(root, tool string) string {
+	for _, file := range miseFiles {
+		content, err := os.ReadFile(filepath.Join(root, file))
+		if err != nil {
+			continue
+		}
+		// Values may be strings, arrays or inline tables; take the first version in them
+		if version := versionNumber.FindString(sectionValues(string(content), "tools")[tool]); version != "" {
+			return version
+		}
+	}
+	return ""
+}
@@ -0,0 +1,34 @@
+package scanner
+
+import "testing"
+
+func TestDetectToolchainVersions(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod":       "module example.com/app\n\ngo 1.22\n\ntoolchain go1.22.4\n",
+		"package.json": `{"engines": {"node": ">=18.17 <21"}}`,
+		"mise.toml":    "[tools]\ngo = \"1.21\"\nnode = \"20\"\n",
+	})
+
+	if got := detectGoVersion(root); got != "1.22" {
+		t.Errorf("detectGoVersion() = %q, want 1.22", got)
+	}
+	if got := detectNodeVersion(root); got != "18.17" {
+		t.Errorf("detectNodeVersion() = %q, want 18.17", got)
+	}
+}
+
+func TestDetectToolchainVersions_Mise(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"package.json": `{"name": "app"}`,
+		"mise.toml":    "[tools]\ngo = \"1.21\"\nnode = [\"20.11\", \"18\"]\n",
+	})
+
+	if got := detectGoVersion(root); got != "1.21" {
+		t.Errorf("detectGoVersion() = %q, want 1.21", got)
+	}
+	if got := detectNodeVersion(root); got != "20.11" {
+		t.Errorf("detectNodeVersion() = %q, want 20.11", got)
+	}
+}
@@ -0,0 +1,245 @@
+package section
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hulk510/readme-gen/internal/config"
+	"github.com/hulk510/readme-gen/internal/scanner"
+	"gopkg.in/yaml.v3"
+)
+
+// Badge is an image, usually a shields.io badge, in the badges section
+type Badge struct {
+	// Label is the alt text of the image
+	Label string
+	// Image is the URL of the badge image
+	Image string
+	// Link is the URL the badge links to (optional)
+	Link string
+}
+
+// Markdown returns the badge as a Markdown image, linked if Link is set
+func (b Badge) Markdown() string {
+	image := fmt.Sprintf("![%s](%s)", b.Label, b.Image)
+	if b.Link == "" {
+		return image
+	}
+	return fmt.Sprintf("[%s](%s)", image, b.Link)
+}
+
+// ShieldsURL returns the URL of a shields.io static badge
+func ShieldsURL(label, message, color string) string {
+	return fmt.Sprintf("https://img.shields.io/badge/%s-%s-%s", shieldsEscape(label), shieldsEscape(message), url.PathEscape(color))
+}
+
+// shieldsEscape escapes text for a shields.io static badge path segment
+func shieldsEscape(s string) string {
+	s = strings.ReplaceAll(s, "-", "--")
+	s = strings.ReplaceAll(s, "_", "__")
+	s = strings.ReplaceAll(s, " ", "_")
+	return url.PathEscape(s)
+}
+
+// renderBadges renders the badges of the project at root, one per line
+func renderBadges(root, lang string, cfg *config.Config) (string, error) {
+	info := scanner.DetectProjectInfo(root)
+	badges, err := DetectBadges(root, info, cfg.Badges)
+	if err != nil {
+		return "", err
+	}
+
+	lines := make([]string, len(badges))
+	for i, b := range badges {
+		lines[i] = b.Markdown()
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// DetectBadges returns the badges that apply to the project, in the order
+// of cfg.Include (default: config.AllBadges), followed by custom badges
+func DetectBadges(root string, info scanner.ProjectInfo, cfg config.BadgesConfig) ([]Badge, error) {
+	kinds := cfg.Include
+	if len(kinds) == 0 {
+		kinds = config.AllBadges
+	}
+
+	var badges []Badge
+	for _, kind := range kinds {
+		if slices.Contains(cfg.Exclude, kind) {
+			continue
+		}
+		switch kind {
+		case config.BadgeLicense:
+			if info.License != "" {
+				badges = append(badges, Badge{
+					Label: "License: " + info.License,
+					Image: ShieldsURL("license", info.License, "blue"),
+					Link:  info.LicenseFile,
+				})
+			}
+		case config.BadgeGoReference:
+			if module := goModule(info); module != "" {
+				badges = append(badges, Badge{
+					Label: "Go Reference",
+					Image: "https://pkg.go.dev/badge/" + module + ".svg",
+					Link:  "https://pkg.go.dev/" + module,
+				})
+			}
+		case config.BadgeGoReportCard:
+			// Go Report Card can only fetch modules from a public host
+			if module := goModule(info); strings.Contains(strings.Split(module, "/")[0], ".") {
+				badges = append(badges, Badge{
+					Label: "Go Report Card",
+					Image: "https://goreportcard.com/badge/" + module,
+					Link:  "https://goreportcard.com/report/" + module,
+				})
+			}
+		case config.BadgeNpm:
+			for _, m := range info.Ecosystems {
+				if m.Ecosystem == scanner.EcosystemNpm && m.Name != "" && !m.Private {
+					badges = append(badges, Badge{
+						Label: "npm",
+						Image: "https://img.shields.io/npm/v/" + m.Name,
+						Link:  "https://www.npmjs.com/package/" + m.Name,
+					})
+					break
+				}
+			}
+		case config.BadgeCI:
+			repo := githubRepo(info.RepoURL)
+			if repo == "" {
+				continue
+			}
+			workflows, err := detectWorkflows(root, cfg.Workflows)
+			if err != nil {
+				return nil, err
+			}
+			for _, w := range workflows {
+				badges = append(badges, Badge{
+					Label: w.name,
+					Image: info.RepoURL + "/actions/workflows/" + w.file + "/badge.svg",
+					Link:  info.RepoURL + "/actions/workflows/" + w.file,
+				})
+			}
+		case config.BadgeRelease:
+			if repo := githubRepo(info.RepoURL); repo != "" {
+				badges = append(badges, Badge{
+					Label: "Release",
+					Image: "https://img.shields.io/github/v/release/" + repo,
+					Link:  info.RepoURL + "/releases/latest",
+				})
+			}
+		}
+	}
+
+	for _, custom := range cfg.Custom {
+		badges = append(badges, Badge{Label: custom.Label, Image: custom.Image, Link: custom.Link})
+	}
+	return badges, nil
+}
+
+// goModule returns the module path of the project's go.mod, if any
+func goModule(info scanner.ProjectInfo) string {
+	for _, m := range info.Ecosystems {
+		if m.Ecosystem == scanner.EcosystemGo {
+			return m.ModulePath
+		}
+	}
+	return ""
+}
+
+// githubRepo returns "owner/repo" for a GitHub repository URL
+func githubRepo(repoURL string) string {
+	repo, ok := strings.CutPrefix(repoURL, "https://github.com/")
+	if !ok || strings.Count(repo, "/") != 1 {
+		return ""
+	}
+	return repo
+}
+
+// workflow is a GitHub Actions workflow file
+type workflow struct {
+	file string
+	name string
+}
+
+// workflowsDir holds the GitHub Actions workflows of a repository
+const workflowsDir = ".github/workflows"
+
+// detectWorkflows returns the workflows shown as CI badges: the files listed
+// in only, in that order, or else every workflow triggered by pull_request
+func detectWorkflows(root string, only []string) ([]workflow, error) {
+	dir := filepath.Join(root, filepath.FromSlash(workflowsDir))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", workflowsDir, err)
+	}
+
+	files := only
+	if len(files) == 0 {
+		for _, entry := range entries {
+			if ext := filepath.Ext(entry.Name()); !entry.IsDir() && (ext == ".yml" || ext == ".yaml") {
+				files = append(files, entry.Name())
+			}
+		}
+		sort.Strings(files)
+	}
+
+	var workflows []workflow
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		var def struct {
+			Name string    `yaml:"name"`
+			On   yaml.Node `yaml:"on"`
+		}
+		if err := yaml.Unmarshal(content, &def); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		if len(only) == 0 && !triggeredBy(def.On, "pull_request") {
+			continue
+		}
+		name := def.Name
+		if name == "" {
+			name = strings.TrimSuffix(file, filepath.Ext(file))
+		}
+		workflows = append(workflows, workflow{file: file, name: name})
+	}
+	return workflows, nil
+}
+
+// triggeredBy reports whether the on: node of a workflow lists event
+// (on: pull_request, on: [push, pull_request] or on: {pull_request: ...})
+func triggeredBy(on yaml.Node, event string) bool {
+	switch on.Kind {
+	case yaml.ScalarNode:
+		return on.Value == event
+	case yaml.SequenceNode:
+		for _, n := range on.Content {
+			if n.Value == event {
+				return true
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(on.Content); i += 2 {
+			if on.Content[i].Value == event {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package section
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hulk510/readme-gen/internal/config"
+	"github.com/hulk510/readme-gen/internal/scanner"
+	"github.com/hulk510/readme-gen/internal/testutil"
+)
+
+func TestDetectBadges(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".github/workflows/ci.yml":      "name: CI\non:\n  push:\n  pull_request:\n",
+		".github/workflows/lint.yaml":   "on: [pull_request]\n",
+		".github/workflows/release.yml": "name: Release\non:\n  push:\n    tags: ['v*']\n",
+	}
+	testutil.WriteFiles(t, root, files)
+
+	info := scanner.ProjectInfo{
+		License:     "Apache-2.0",
+		LicenseFile: "LICENSE",
+		RepoURL:     "https://github.com/owner/tool",
+		Ecosystems: []scanner.Manifest{
+			{Ecosystem: scanner.EcosystemGo, ModulePath: "github.com/owner/tool"},
+			{Ecosystem: scanner.EcosystemNpm, Name: "@owner/tool"},
+		},
+	}
+
+	badges, err := DetectBadges(root, info, config.BadgesConfig{})
+	if err != nil {
+		t.Fatalf("DetectBadges() error = %v", err)
+	}
+	var got []string
+	for _, b := range badges {
+		got = append(got, b.Markdown())
+	}
+	want := []string{
+		"[![License: Apache-2.0](https://img.shields.io/badge/license-Apache--2.0-blue)](LICENSE)",
+		"[![Go Reference](https://pkg.go.dev/badge/github.com/owner/tool.svg)](https://pkg.go.dev/github.com/owner/tool)",
+		"[![Go Report Card](https://goreportcard.com/badge/github.com/owner/tool)](https://goreportcard.com/report/github.com/owner/tool)",
+		"[![npm](https://img.shields.io/npm/v/@owner/tool)](https://www.npmjs.com/package/@owner/tool)",
+		"[![CI](https://github.com/owner/tool/actions/workflows/ci.yml/badge.svg)](https://github.com/owner/tool/actions/workflows/ci.yml)",
+		"[![lint](https://github.com/owner/tool/actions/workflows/lint.yaml/badge.svg)](https://github.com/owner/tool/actions/workflows/lint.yaml)",
+		"[![Release](https://img.shields.io/github/v/release/owner/tool)](https://github.com/owner/tool/releases/latest)",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("DetectBadges() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Include orders badges, Exclude drops them, Workflows selects files
+	badges, err = DetectBadges(root, info, config.BadgesConfig{
+		Include:   []string{config.BadgeCI, config.BadgeLicense, config.BadgeRelease},
+		Exclude:   []string{config.BadgeRelease},
+		Workflows: []string{"release.yml"},
+		Custom:    []config.CustomBadge{{Label: "Chat", Image: "https://example.com/chat.svg"}},
+	})
+	if err != nil {
+		t.Fatalf("DetectBadges() error = %v", err)
+	}
+	var labels []string
+	for _, b := range badges {
+		labels = append(labels, b.Label)
+	}
+	if got := strings.Join(labels, ","); got != "Release,License: Apache-2.0,Chat" {
+		t.Errorf("labels = %s, want Release,License: Apache-2.0,Chat", got)
+	}
+}
+
+func TestDetectBadges_NoMetadata(t *testing.T) {
+	info := scanner.ProjectInfo{
+		RepoURL:    "https://gitlab.com/owner/tool",
+		Ecosystems: []scanner.Manifest{{Ecosystem: scanner.EcosystemNpm, Name: "app", Private: true}},
+	}
+	badges, err := DetectBadges(t.TempDir(), info, config.BadgesConfig{})
+	if err != nil {
+		t.Fatalf("DetectBadges() error = %v", err)
+	}
+	if len(badges) != 0 {
+		t.Errorf("expected no badges, got %+v", badges)
+	}
+}
@@ -0,0 +1,32 @@
+package section
+
+import (
+	"github.com/hulk510/readme-gen/internal/config"
+	"github.com/hulk510/readme-gen/internal/scanner"
+)
+
+// envHeaders are the column headings of the env table by language
+var envHeaders = map[string][]string{
+	"en": {"Variable", "Default", "Description"},
+	"ja": {"変数", "デフォルト", "説明"},
+}
+
+// renderEnv renders the environment variables of the project at root as a
+// Markdown table
+func renderEnv(root, lang string, cfg *config.Config) (string, error) {
+	return envTable(scanner.DetectEnvVars(root, cfg.Env.GetFiles(), cfg.Env.ScanGo), lang), nil
+}
+
+// envTable renders vars as a table of names, defaults and descriptions, or
+// nothing when there are no variables
+func envTable(vars []scanner.EnvVar, lang string) string {
+	rows := make([][]string, len(vars))
+	for i, v := range vars {
+		value := ""
+		if v.Default != "" {
+			value = "`" + v.Default + "`"
+		}
+		rows[i] = []string{"`" + v.Name + "`", tableCell(value), tableCell(v.Description)}
+	}
+	return markdownTable(localized(envHeaders, lang), rows)
+}
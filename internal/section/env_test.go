@@ -0,0 +1,31 @@
+package section
+
+import (
+	"testing"
+
+	"github.com/hulk510/readme-gen/internal/scanner"
+)
+
+func TestEnvTable(t *testing.T) {
+	vars := []scanner.EnvVar{
+		{Name: "PORT", Default: "8080", Description: "Port to listen on"},
+		{Name: "MODE", Default: "a|b", Description: "One of a | b"},
+		{Name: "API_KEY"},
+	}
+
+	want := "| Variable | Default | Description |\n" +
+		"|----------|---------|-------------|\n" +
+		"| `PORT` | `8080` | Port to listen on |\n" +
+		"| `MODE` | `a\\|b` | One of a \\| b |\n" +
+		"| `API_KEY` |  |  |"
+	if got := envTable(vars, "en"); got != want {
+		t.Errorf("envTable() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := envTable(vars[:1], "ja"); got != "| 変数 | デフォルト | 説明 |\n|----|-------|----|\n| `PORT` | `8080` | Port to listen on |" {
+		t.Errorf("envTable(ja) = %q", got)
+	}
+	if got := envTable(nil, "en"); got != "" {
+		t.Errorf("envTable(nil) = %q, want empty", got)
+	}
+}
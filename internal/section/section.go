@@ -0,0 +1,86 @@
+package section
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hulk510/readme-gen/internal/config"
+)
+
+// Names of the generated sections
+const (
+	// Badges is the row of badges below the title
+	Badges = "badges"
+	// Tasks is the table of development commands
+	Tasks = "tasks"
+	// Env is the table of environment variables
+	Env = "env"
+)
+
+// Generator renders the body of a generated section for the project at root,
+// in the language of the README (en, ja)
+type Generator func(root, lang string, cfg *config.Config) (string, error)
+
+// generators are the managed sections readme-gen generates, by name
+var generators = map[string]Generator{
+	Badges: renderBadges,
+	Tasks:  renderTasks,
+	Env:    renderEnv,
+}
+
+// Known reports whether readme-gen generates the section name
+func Known(name string) bool {
+	_, ok := generators[name]
+	return ok
+}
+
+// Names returns the names of the generated sections, sorted
+func Names() []string {
+	names := make([]string, 0, len(generators))
+	for name := range generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render renders the body of the section name for the project at root
+func Render(name, root, lang string, cfg *config.Config) (string, error) {
+	generate, ok := generators[name]
+	if !ok {
+		return "", fmt.Errorf("unknown section '%s'", name)
+	}
+	return generate(root, lang, cfg)
+}
+
+// localized returns the text for lang, falling back to English
+func localized(texts map[string][]string, lang string) []string {
+	if text, ok := texts[lang]; ok {
+		return text
+	}
+	return texts["en"]
+}
+
+// markdownTable renders a Markdown table, or nothing when there are no rows.
+// Cells must already be escaped with tableCell.
+func markdownTable(headers []string, rows [][]string) string {
+	if len(rows) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(headers, " | ") + " |\n|")
+	for _, header := range headers {
+		b.WriteString(strings.Repeat("-", len([]rune(header))+2) + "|")
+	}
+	for _, row := range rows {
+		b.WriteString("\n| " + strings.Join(row, " | ") + " |")
+	}
+	return b.String()
+}
+
+// tableCell escapes text for a Markdown table cell
+func tableCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,27 @@
+package section
+
+import (
+	"github.com/hulk510/readme-gen/internal/config"
+	"github.com/hulk510/readme-gen/internal/scanner"
+)
+
+// taskHeaders are the column headings of the tasks table by language
+var taskHeaders = map[string][]string{
+	"en": {"Command", "Description"},
+	"ja": {"コマンド", "説明"},
+}
+
+// renderTasks renders the tasks of the project at root as a Markdown table
+func renderTasks(root, lang string, cfg *config.Config) (string, error) {
+	return tasksTable(scanner.DetectTasks(root), lang), nil
+}
+
+// tasksTable renders tasks as a table of commands and descriptions, or
+// nothing when there are no tasks
+func tasksTable(tasks []scanner.Task, lang string) string {
+	rows := make([][]string, len(tasks))
+	for i, task := range tasks {
+		rows[i] = []string{"`" + task.Command + "`", tableCell(task.Description)}
+	}
+	return markdownTable(localized(taskHeaders, lang), rows)
+}
@@ -0,0 +1,31 @@
+package section
+
+import (
+	"testing"
+
+	"github.com/hulk510/readme-gen/internal/scanner"
+)
+
+func TestTasksTable(t *testing.T) {
+	tasks := []scanner.Task{
+		{Command: "mise run build", Description: "Build the binary"},
+		{Command: "npm run lint", Description: "Lint a | b"},
+		{Command: "make test"},
+	}
+
+	want := "| Command | Description |\n" +
+		"|---------|-------------|\n" +
+		"| `mise run build` | Build the binary |\n" +
+		"| `npm run lint` | Lint a \\| b |\n" +
+		"| `make test` |  |"
+	if got := tasksTable(tasks, "en"); got != want {
+		t.Errorf("tasksTable() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := tasksTable(tasks[:1], "ja"); got != "| コマンド | 説明 |\n|------|----|\n| `mise run build` | Build the binary |" {
+		t.Errorf("tasksTable(ja) = %q", got)
+	}
+	if got := tasksTable(nil, "en"); got != "" {
+		t.Errorf("tasksTable(nil) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,125 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/hulk510/readme-gen/internal/config"
+	"github.com/hulk510/readme-gen/internal/marker"
+	"github.com/hulk510/readme-gen/internal/scanner"
+	"github.com/hulk510/readme-gen/internal/section"
+)
+
+// funcMap returns the helper functions available to templates. Project
+// helpers (tree, file, exists, badges, tasks, env) resolve paths relative
+// to root; tree, tasks and env use lang for descriptions and headings.
+func funcMap(root, lang string) template.FuncMap {
+	return template.FuncMap{
+		// Strings
+		"upper":     strings.ToUpper,
+		"lower":     strings.ToLower,
+		"title":     title,
+		"trim":      strings.TrimSpace,
+		"replace":   replace,
+		"join":      join,
+		"split":     split,
+		"contains":  contains,
+		"hasPrefix": hasPrefix,
+		"hasSuffix": hasSuffix,
+		"default":   defaultString,
+
+		// Project
+		"tree": func(path string, levels int) (string, error) {
+			cfg, err := config.Load(root)
+			if err != nil {
+				return "", err
+			}
+			return scanner.Structure(root, path, cfg, lang, levels)
+		},
+		"file": func(path string) (string, error) {
+			content, err := os.ReadFile(projectPath(root, path))
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimRight(string(content), "\n"), nil
+		},
+		"exists": func(path string) bool {
+			_, err := os.Stat(projectPath(root, path))
+			return err == nil
+		},
+		"badge": badge,
+		"badges": func() (string, error) {
+			return managedSection(root, lang, section.Badges)
+		},
+		"tasks": func() (string, error) {
+			return managedSection(root, lang, section.Tasks)
+		},
+		"env": func() (string, error) {
+			return managedSection(root, lang, section.Env)
+		},
+	}
+}
+
+// managedSection renders the generated section name wrapped in its markers,
+// so that `readme-gen update` can refresh it later
+func managedSection(root, lang, name string) (string, error) {
+	cfg, err := config.Load(root)
+	if err != nil {
+		return "", err
+	}
+	body, err := section.Render(name, root, lang, cfg)
+	if err != nil {
+		return "", err
+	}
+	return marker.WrapManaged(name, body), nil
+}
+
+// projectPath resolves a template-supplied path inside root
+func projectPath(root, path string) string {
+	return filepath.Join(root, filepath.FromSlash(config.NormalizePath(path)))
+}
+
+// title upper-cases the first letter of each word, treating "-" and "_"
+// as word separators ("readme-gen" -> "Readme Gen")
+func title(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-' || r == '_'
+	})
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
+
+// The following helpers take the subject last so that they work in pipelines:
+// {{.ProjectName | replace "-" " "}}
+
+func replace(old, new, s string) string      { return strings.ReplaceAll(s, old, new) }
+func join(sep string, elems []string) string { return strings.Join(elems, sep) }
+func split(sep, s string) []string           { return strings.Split(s, sep) }
+func contains(substr, s string) bool         { return strings.Contains(s, substr) }
+func hasPrefix(prefix, s string) bool        { return strings.HasPrefix(s, prefix) }
+func hasSuffix(suffix, s string) bool        { return strings.HasSuffix(s, suffix) }
+
+// defaultString returns value, or def if value is empty
+func defaultString(def, value string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
+
+// badge returns a Markdown shields.io badge, linked to link if given:
+// {{badge "license" "MIT" "blue" "LICENSE"}}
+func badge(label, message, color string, link ...string) string {
+	b := section.Badge{Label: label, Image: section.ShieldsURL(label, message, color)}
+	if len(link) > 0 {
+		b.Link = link[0]
+	}
+	return b.Markdown()
+}
@@ -0,0 +1,179 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hulk510/readme-gen/internal/i18n"
+)
+
+func TestTitle(t *testing.T) {
+	tests := map[string]string{
+		"readme-gen":    "Readme Gen",
+		"my_cool app":   "My Cool App",
+		"already Title": "Already Title",
+		"":              "",
+	}
+	for in, want := range tests {
+		if got := title(in); got != want {
+			t.Errorf("title(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestBadge(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{
+			args: []string{"license", "MIT", "blue"},
+			want: "![license](https://img.shields.io/badge/license-MIT-blue)",
+		},
+		{
+			args: []string{"go version", "1.25-rc_1", "00ADD8", "go.mod"},
+			want: "[![go version](https://img.shields.io/badge/go_version-1.25--rc__1-00ADD8)](go.mod)",
+		},
+	}
+	for _, tt := range tests {
+		if got := badge(tt.args[0], tt.args[1], tt.args[2], tt.args[3:]...); got != tt.want {
+			t.Errorf("badge(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestRender_ProjectHelpers(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	for _, dir := range []string{"internal/api/v1", "internal/db", "web"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "LICENSE"), []byte("MIT License\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".readme-gen.yaml"), []byte("descriptions:\n  internal/db: Database access\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writeTemplate(t, ProjectDir(root), "helpers.md.tmpl", `# {{title .ProjectName}}
+{{tree "internal" 1}}
+{{file "LICENSE"}}
+{{if exists "Dockerfile"}}docker{{else}}no docker{{end}}
+{{.Description | default "No description"}}
+{{.Structure}}
+`)
+
+	result, err := NewLoader(root).Render("helpers", Data{ProjectName: "my-app", Lang: i18n.English})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := "# My App\ninternal/\n├── api/\n└── db/    # Database access\nMIT License\nno docker\nNo description\n"
+	if !strings.HasPrefix(result, want) {
+		t.Errorf("Render() =\n%s\nwant prefix\n%s", result, want)
+	}
+}
+
+func TestRender_Extends(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	// A project "oss" that extends the built-in "oss", replacing one block
+	writeTemplate(t, ProjectDir(root), "oss.md.tmpl", `{{/* extends: oss */}}
+{{define "installation"}}## Installation
+
+make install{{end}}
+`)
+	// A differently named template extending the project "oss"
+	writeTemplate(t, ProjectDir(root), "company.md.tmpl", `{{/*
+description: Company layout
+extends: oss
+*/}}
+{{define "license"}}## License
+
+Proprietary{{end}}
+`)
+
+	loader := NewLoader(root)
+	data := Data{ProjectName: "demo", Language: "go", ModulePath: "example.com/demo", Lang: i18n.English}
+
+	result, err := loader.Render("oss", data)
+	if err != nil {
+		t.Fatalf("Render(oss) error = %v", err)
+	}
+	if !strings.Contains(result, "## Installation\n\nmake install\n\n## Usage") {
+		t.Errorf("installation block should be replaced:\n%s", result)
+	}
+	if strings.Contains(result, "go install") || !strings.Contains(result, "## Contributing") {
+		t.Errorf("other blocks should be inherited:\n%s", result)
+	}
+
+	result, err = loader.Render("company", data)
+	if err != nil {
+		t.Fatalf("Render(company) error = %v", err)
+	}
+	if !strings.Contains(result, "make install") || !strings.HasSuffix(result, "## License\n\nProprietary\n") {
+		t.Errorf("blocks should be replaced along the chain:\n%s", result)
+	}
+
+	content, _, err := loader.Load("company", i18n.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Describe(content); got != "Company layout" {
+		t.Errorf("Describe() = %q", got)
+	}
+	if issues := loader.Lint("company", content); len(issues) != 0 {
+		t.Errorf("Lint() = %v, want no issues", issues)
+	}
+}
+
+func TestRender_Partials(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	partials := filepath.Join(ProjectDir(root), "partials")
+	writeTemplate(t, partials, "usage-example.md.tmpl", "Run {{.ProjectName}} --help")
+	writeTemplate(t, partials, "footer.md.tmpl", "Made by {{.ProjectName}}")
+	writeTemplate(t, partials, "footer_ja.md.tmpl", "{{.ProjectName}}製")
+	writeTemplate(t, ProjectDir(root), "team.md.tmpl", "{{.Structure}}\n{{template \"footer\" .}}\n")
+
+	loader := NewLoader(root)
+
+	result, err := loader.Render("oss", Data{ProjectName: "demo", Lang: i18n.English})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(result, "## Usage\n\nRun demo --help\n") {
+		t.Errorf("project partial should override the built-in one:\n%s", result)
+	}
+
+	result, err = loader.Render("team", Data{ProjectName: "demo", Lang: i18n.Japanese})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.HasSuffix(result, "demo製\n") {
+		t.Errorf("expected Japanese partial, got:\n%s", result)
+	}
+}
+
+func TestRender_ServiceEnv(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := os.WriteFile(filepath.Join(root, ".env.example"), []byte("# Port to listen on\nPORT=8080\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := NewLoader(root).Render("service", Data{ProjectName: "api", Language: "go", Lang: i18n.English})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "cp .env.example .env\n```\n\n<!-- readme-gen:env:start -->\n| Variable | Default | Description |\n|----------|---------|-------------|\n| `PORT` | `8080` | Port to listen on |\n<!-- readme-gen:env:end -->"
+	if !strings.Contains(result, want) {
+		t.Errorf("Render() should contain %q, got:\n%s", want, result)
+	}
+}
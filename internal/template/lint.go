@@ -0,0 +1,147 @@
+package template
+
+import (
+	"reflect"
+	"text/template/parse"
+
+	"github.com/hulk510/readme-gen/internal/i18n"
+)
+
+// LintKind classifies a problem found by Lint
+type LintKind int
+
+const (
+	// LintParse is a template syntax error
+	LintParse LintKind = iota
+	// LintMissingStructure means the template never references .Structure,
+	// so the README would have no managed structure section
+	LintMissingStructure
+	// LintUnknownField is a reference to a field that Data does not have
+	LintUnknownField
+)
+
+// LintIssue describes a problem with a template
+type LintIssue struct {
+	Kind LintKind
+	// Location is "name:line:col" of the problem (empty for LintMissingStructure)
+	Location string
+	// Detail is the unknown field name (LintUnknownField) or the parse error (LintParse)
+	Detail string
+}
+
+// Lint checks a built-in template. See Loader.Lint.
+func Lint(name, content string) []LintIssue {
+	return builtinLoader.Lint(name, content)
+}
+
+// Lint parses a template together with the partials and the templates it
+// extends, and verifies that it references .Structure and only fields of
+// Data. Fields inside range and with blocks refer to other values and are
+// not checked, except when accessed through $.
+func (l *Loader) Lint(name, content string) []LintIssue {
+	idx := len(l.sources)
+	if _, found, err := l.find(name, i18n.English, 0); err == nil {
+		idx = found
+	}
+	set, _, err := l.parse(name, content, idx, i18n.English, funcMap(l.root, string(i18n.English)))
+	if err != nil {
+		return []LintIssue{{Kind: LintParse, Detail: err.Error()}}
+	}
+
+	lt := &linter{fields: dataFields()}
+	for _, t := range set.Templates() {
+		if t.Tree == nil || t.Tree.Root == nil {
+			continue
+		}
+		lt.tree = t.Tree
+		lt.walk(t.Tree.Root, true)
+	}
+
+	if !lt.hasStructure {
+		lt.issues = append([]LintIssue{{Kind: LintMissingStructure}}, lt.issues...)
+	}
+	return lt.issues
+}
+
+// dataFields returns the names of the fields and methods of Data
+func dataFields() map[string]bool {
+	fields := make(map[string]bool)
+	typ := reflect.TypeOf(Data{})
+	for i := 0; i < typ.NumField(); i++ {
+		fields[typ.Field(i).Name] = true
+	}
+	for i := 0; i < typ.NumMethod(); i++ {
+		fields[typ.Method(i).Name] = true
+	}
+	return fields
+}
+
+// linter collects field references while walking template trees
+type linter struct {
+	tree         *parse.Tree
+	fields       map[string]bool
+	hasStructure bool
+	issues       []LintIssue
+}
+
+// walk visits node. topLevel reports whether dot is the Data value.
+func (l *linter) walk(node parse.Node, topLevel bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			l.walk(child, topLevel)
+		}
+	case *parse.ActionNode:
+		l.walk(n.Pipe, topLevel)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			l.walk(cmd, topLevel)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			l.walk(arg, topLevel)
+		}
+	case *parse.ChainNode:
+		l.walk(n.Node, topLevel)
+	case *parse.FieldNode:
+		if topLevel {
+			l.field(n, n.Ident[0])
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			l.field(n, n.Ident[1])
+		}
+	case *parse.IfNode:
+		l.walk(n.Pipe, topLevel)
+		l.walk(n.List, topLevel)
+		l.walk(n.ElseList, topLevel)
+	case *parse.RangeNode:
+		l.walk(n.Pipe, topLevel)
+		l.walk(n.List, false)
+		l.walk(n.ElseList, topLevel)
+	case *parse.WithNode:
+		l.walk(n.Pipe, topLevel)
+		l.walk(n.List, false)
+		l.walk(n.ElseList, topLevel)
+	case *parse.TemplateNode:
+		l.walk(n.Pipe, topLevel)
+	}
+}
+
+// field records a reference to a field of Data
+func (l *linter) field(node parse.Node, name string) {
+	if name == "Structure" {
+		l.hasStructure = true
+	}
+	if l.fields[name] {
+		return
+	}
+	location, _ := l.tree.ErrorContext(node)
+	l.issues = append(l.issues, LintIssue{Kind: LintUnknownField, Location: location, Detail: name})
+}
@@ -0,0 +1,91 @@
+package template
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hulk510/readme-gen/internal/i18n"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "valid",
+			content: "# {{.ProjectName}}\n{{if .Description}}{{.Description}}{{end}}\n{{.Structure}}\n",
+		},
+		{
+			name:    "unknown field",
+			content: "# {{.Name}}\n\n{{.Structure}}\n",
+			want:    []string{"unknown:valid:1:4:Name"},
+		},
+		{
+			name:    "missing structure",
+			content: "# {{.ProjectName}}\n",
+			want:    []string{"missing"},
+		},
+		{
+			name:    "range changes dot",
+			content: "{{range .Structure}}{{.Anything}}{{$.Author}}{{end}}\n",
+			want:    []string{"unknown:valid:1:36:Author"},
+		},
+		{
+			name:    "methods",
+			content: "{{.Structure}}{{range .Ecosystems}}{{$.WithEcosystem .}}{{end}}\n",
+		},
+		{
+			name:    "parse error",
+			content: "{{if .Structure}}\n",
+			want:    []string{"parse"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range Lint("valid", tt.content) {
+				switch issue.Kind {
+				case LintParse:
+					got = append(got, "parse")
+				case LintMissingStructure:
+					got = append(got, "missing")
+				case LintUnknownField:
+					got = append(got, "unknown:"+issue.Location+":"+issue.Detail)
+				}
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("Lint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLint_Builtins(t *testing.T) {
+	for _, info := range builtinLoader.List() {
+		for _, lang := range info.Langs {
+			content, _, err := builtinLoader.Load(info.Name, lang)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if issues := Lint(info.Name, content); len(issues) != 0 {
+				t.Errorf("built-in template %s (%s) has issues: %v", info.Name, lang, issues)
+			}
+			if Describe(content) == "" {
+				t.Errorf("built-in template %s (%s) has no description", info.Name, lang)
+			}
+		}
+	}
+}
+
+func TestRender_DescriptionHeaderIsNotRendered(t *testing.T) {
+	result, err := Render("oss", Data{ProjectName: "demo", Lang: i18n.Japanese})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.HasPrefix(result, "# demo\n") {
+		t.Errorf("expected README to start with the title, got: %q", result[:20])
+	}
+}
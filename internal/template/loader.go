@@ -0,0 +1,418 @@
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/hulk510/readme-gen/internal/config"
+	"github.com/hulk510/readme-gen/internal/fileutil"
+	"github.com/hulk510/readme-gen/internal/i18n"
+	"github.com/hulk510/readme-gen/internal/marker"
+)
+
+// Template sources, in lookup order
+const (
+	// SourceProject is .readme-gen/templates in the project
+	SourceProject = "project"
+	// SourceUser is readme-gen/templates in the user config directory
+	SourceUser = "user"
+	// SourceBuiltin is the templates embedded in the binary
+	SourceBuiltin = "builtin"
+)
+
+// templateExt is the file extension of template files
+const templateExt = ".md.tmpl"
+
+// partialsDir is the directory of shared partials within a source.
+// Each file defines a template named after it (e.g. partials/badges.md.tmpl
+// is included with {{template "badges" .}}).
+const partialsDir = "partials"
+
+// maxExtends limits the length of an extends chain
+const maxExtends = 8
+
+// Source is a directory that templates are loaded from
+type Source struct {
+	// Kind is SourceProject, SourceUser or SourceBuiltin
+	Kind string
+	// Dir is the directory shown to users (empty for built-ins)
+	Dir string
+
+	fsys fs.FS
+}
+
+// Loader looks up templates by name across sources. A template found in an
+// earlier source overrides templates of the same name in later ones.
+type Loader struct {
+	root    string
+	sources []Source
+}
+
+// builtinSource returns the source of the embedded templates
+func builtinSource() Source {
+	sub, _ := fs.Sub(templatesFS, "templates")
+	return Source{Kind: SourceBuiltin, fsys: sub}
+}
+
+// NewLoader returns a loader that searches the project's .readme-gen/templates,
+// then the user's template directory, then the built-in templates
+func NewLoader(root string) *Loader {
+	var sources []Source
+	dirs := []Source{
+		{Kind: SourceProject, Dir: ProjectDir(root)},
+		{Kind: SourceUser, Dir: UserDir()},
+	}
+	for _, src := range dirs {
+		if src.Dir == "" {
+			continue
+		}
+		if info, err := os.Stat(src.Dir); err != nil || !info.IsDir() {
+			continue
+		}
+		src.fsys = os.DirFS(src.Dir)
+		sources = append(sources, src)
+	}
+	return &Loader{root: root, sources: append(sources, builtinSource())}
+}
+
+// ProjectDir returns the project template directory for root
+func ProjectDir(root string) string {
+	return filepath.Join(root, config.WorkDir, "templates")
+}
+
+// UserDir returns the user template directory:
+// $XDG_CONFIG_HOME/readme-gen/templates, falling back to ~/.config
+func UserDir() string {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		base = filepath.Join(home, ".config")
+	}
+	return filepath.Join(base, "readme-gen", "templates")
+}
+
+// Info describes an available template
+type Info struct {
+	Name   string
+	Source Source
+	// Langs lists the languages the template has variants for
+	Langs []i18n.Language
+}
+
+// List returns all available templates sorted by name. Overridden templates
+// are reported once, with the source that wins.
+func (l *Loader) List() []Info {
+	found := make(map[string]*Info)
+	var names []string
+	for _, src := range l.sources {
+		files, err := fs.Glob(src.fsys, "*"+templateExt)
+		if err != nil {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, file := range files {
+			name, lang := splitTemplateFile(file)
+			if info, ok := found[name]; ok {
+				if seen[name] {
+					info.Langs = append(info.Langs, lang)
+				}
+				continue
+			}
+			found[name] = &Info{Name: name, Source: src, Langs: []i18n.Language{lang}}
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	infos := make([]Info, 0, len(names))
+	for _, name := range names {
+		info := found[name]
+		sort.Slice(info.Langs, func(i, j int) bool { return info.Langs[i] < info.Langs[j] })
+		infos = append(infos, *info)
+	}
+	return infos
+}
+
+// Load returns the content of the template in the given language and the
+// source it came from. The first source that has any variant of the template
+// is used; within it the English variant is the fallback for missing languages.
+func (l *Loader) Load(name string, lang i18n.Language) (string, Source, error) {
+	content, idx, err := l.find(name, lang, 0)
+	if err != nil {
+		return "", Source{}, err
+	}
+	return content, l.sources[idx], nil
+}
+
+// Render renders the named template with the given data
+func (l *Loader) Render(name string, data Data) (string, error) {
+	content, idx, err := l.find(name, data.Lang, 0)
+	if err != nil {
+		return "", err
+	}
+	return l.render(name, content, idx, data)
+}
+
+// RenderContent renders template source that does not come from a template
+// directory (e.g. a README.md.tmpl in the project). It can use partials and
+// extend other templates like any other template.
+func (l *Loader) RenderContent(name, content string, data Data) (string, error) {
+	return l.render(name, content, len(l.sources), data)
+}
+
+// render parses content as the template name from source idx and executes it
+func (l *Loader) render(name, content string, idx int, data Data) (string, error) {
+	tmpl, entry, err := l.parse(name, content, idx, data.Lang, funcMap(l.root, string(data.Lang)))
+	if err != nil {
+		return "", err
+	}
+
+	// Wrap structure with markers
+	data.Structure = marker.Wrap(data.Structure)
+	if data.Ecosystem == "" {
+		data.Ecosystem = defaultEcosystems[data.Language]
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, entry, data); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// find returns the content of the template and the index of its source,
+// searching sources from index from
+func (l *Loader) find(name string, lang i18n.Language, from int) (string, int, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", 0, fmt.Errorf("invalid template name '%s'", name)
+	}
+
+	for i := from; i < len(l.sources); i++ {
+		content, err := readVariant(l.sources[i].fsys, name, lang)
+		if err == nil {
+			return content, i, nil
+		}
+	}
+	return "", 0, fmt.Errorf("template '%s' not found", name)
+}
+
+// parse builds the template set for the template name with the given
+// content from source idx: the partials of all sources, the templates it
+// extends, and the template itself. It returns the set and the name of the
+// template to execute.
+func (l *Loader) parse(name, content string, idx int, lang i18n.Language, funcs template.FuncMap) (*template.Template, string, error) {
+	set := template.New("").Funcs(funcs)
+	if err := l.parsePartials(set, lang); err != nil {
+		return nil, "", err
+	}
+
+	entry, err := l.parseInto(set, name, content, idx, lang, 0)
+	if err != nil {
+		return nil, "", err
+	}
+	return set, entry, nil
+}
+
+// parsePartials adds the partials of all sources to set. Partials of
+// earlier sources replace those of later ones.
+func (l *Loader) parsePartials(set *template.Template, lang i18n.Language) error {
+	for i := len(l.sources) - 1; i >= 0; i-- {
+		fsys := l.sources[i].fsys
+		files, err := fs.Glob(fsys, partialsDir+"/*"+templateExt)
+		if err != nil {
+			return err
+		}
+
+		parsed := make(map[string]bool)
+		for _, file := range files {
+			name, _ := splitTemplateFile(path.Base(file))
+			if parsed[name] {
+				continue
+			}
+			parsed[name] = true
+
+			content, err := readVariant(fsys, path.Join(partialsDir, name), lang)
+			if err != nil {
+				return err
+			}
+			if _, err := set.New(name).Parse(content); err != nil {
+				return fmt.Errorf("failed to parse partial %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// parseInto adds the template name, found in source idx with the given
+// content, to set and returns the name of the template to execute. A
+// template whose header says "extends: base" is parsed after its base, so
+// that its {{define}} blocks replace the base's blocks; the base is then
+// executed. A template extending its own name extends the same template
+// from a later source (e.g. a project oss.md.tmpl extending the built-in one).
+func (l *Loader) parseInto(set *template.Template, name, content string, idx int, lang i18n.Language, depth int) (string, error) {
+	base := parseHeader(content)["extends"]
+	if base == "" {
+		if _, err := set.New(name).Parse(content); err != nil {
+			return "", fmt.Errorf("failed to parse template: %w", err)
+		}
+		return name, nil
+	}
+
+	if depth >= maxExtends {
+		return "", fmt.Errorf("template '%s' extends too many templates", name)
+	}
+
+	from := 0
+	if base == name {
+		from = idx + 1
+	}
+	baseContent, baseIdx, err := l.find(base, lang, from)
+	if err != nil {
+		return "", fmt.Errorf("template '%s' extends unknown template: %w", name, err)
+	}
+	entry, err := l.parseInto(set, base, baseContent, baseIdx, lang, depth+1)
+	if err != nil {
+		return "", err
+	}
+
+	// Parsed under its own name so that its top-level text does not
+	// replace the body of the base template
+	if _, err := set.New(fmt.Sprintf("%s@%d", name, depth)).Parse(content); err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+	return entry, nil
+}
+
+// headerPattern matches the comment at the start of a template that holds
+// "key: value" settings, one per line:
+//
+//	{{/* description: Company README layout
+//	extends: oss */ -}}
+var headerPattern = regexp.MustCompile(`^\{\{-?\s*/\*((?s).*?)\*/\s*-?\}\}`)
+
+// parseHeader returns the settings of the header comment of a template
+func parseHeader(content string) map[string]string {
+	header := make(map[string]string)
+	m := headerPattern.FindStringSubmatch(content)
+	if m == nil {
+		return header
+	}
+	for _, line := range strings.Split(m[1], "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		header[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return header
+}
+
+// Describe returns the description from the header comment of a template,
+// or "" if there is none
+func Describe(content string) string {
+	return parseHeader(content)["description"]
+}
+
+// Eject copies the built-in template name, with all its language variants,
+// into the project template directory so that it can be customized.
+// Existing files are only replaced when overwrite is set. It returns the
+// written paths.
+func Eject(root, name string, overwrite bool) ([]string, error) {
+	builtin := builtinSource()
+	files, err := fs.Glob(builtin.fsys, name+"*"+templateExt)
+	if err != nil {
+		return nil, err
+	}
+
+	var variants []string
+	for _, file := range files {
+		if base, _ := splitTemplateFile(file); base == name {
+			variants = append(variants, file)
+		}
+	}
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("built-in template '%s' not found", name)
+	}
+
+	dir := ProjectDir(root)
+	if !overwrite {
+		for _, file := range variants {
+			path := filepath.Join(dir, file)
+			if _, err := os.Stat(path); err == nil {
+				return nil, fmt.Errorf("%w: %s", fs.ErrExist, path)
+			}
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	var written []string
+	for _, file := range variants {
+		content, err := fs.ReadFile(builtin.fsys, file)
+		if err != nil {
+			return written, err
+		}
+		path := filepath.Join(dir, file)
+		if err := fileutil.WriteFile(path, content, 0644); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// readVariant reads the language variant of a template, falling back to
+// the English template if the language variant does not exist
+func readVariant(fsys fs.FS, name string, lang i18n.Language) (string, error) {
+	if lang != i18n.English {
+		if content, err := fs.ReadFile(fsys, templateFile(name, lang)); err == nil {
+			return string(content), nil
+		}
+	}
+	content, err := fs.ReadFile(fsys, templateFile(name, i18n.English))
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// templateFile returns the file name of a template variant
+// (e.g. "oss.md.tmpl", "oss_ja.md.tmpl")
+func templateFile(name string, lang i18n.Language) string {
+	if lang == i18n.English {
+		return name + templateExt
+	}
+	return name + "_" + string(lang) + templateExt
+}
+
+// NameOf returns the template name of a template file path
+// (".readme-gen/templates/oss_ja.md.tmpl" -> "oss")
+func NameOf(file string) string {
+	name, _ := splitTemplateFile(filepath.Base(file))
+	return name
+}
+
+// splitTemplateFile returns the template name and language of a file name
+func splitTemplateFile(file string) (string, i18n.Language) {
+	base := strings.TrimSuffix(file, templateExt)
+	for _, lang := range []i18n.Language{i18n.Japanese} {
+		if name, ok := strings.CutSuffix(base, "_"+string(lang)); ok {
+			return name, lang
+		}
+	}
+	return base, i18n.English
+}
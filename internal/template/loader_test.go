@@ -0,0 +1,131 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hulk510/readme-gen/internal/i18n"
+)
+
+// writeTemplate creates a template file under dir
+func writeTemplate(t *testing.T, dir, file, content string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoader_Precedence(t *testing.T) {
+	root := t.TempDir()
+	userHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", userHome)
+
+	userDir := filepath.Join(userHome, "readme-gen", "templates")
+	writeTemplate(t, userDir, "company.md.tmpl", "# {{.ProjectName}} (user)\n")
+	writeTemplate(t, userDir, "team.md.tmpl", "# {{.ProjectName}} (user team)\n")
+	writeTemplate(t, ProjectDir(root), "team.md.tmpl", "# {{.ProjectName}} (project team)\n{{.Structure}}\n")
+	writeTemplate(t, ProjectDir(root), "oss.md.tmpl", "# {{.ProjectName}} (project oss)\n")
+
+	loader := NewLoader(root)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"company", "# demo (user)"},
+		{"team", "# demo (project team)"},
+		{"oss", "# demo (project oss)"},
+		{"general", "# demo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := loader.Render(tt.name, Data{ProjectName: "demo", Lang: i18n.English})
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if !strings.HasPrefix(result, tt.want+"\n") {
+				t.Errorf("Render() = %q, want prefix %q", result, tt.want)
+			}
+		})
+	}
+
+	result, err := loader.Render("team", Data{ProjectName: "demo", Structure: "src/"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(result, "<!-- readme-gen:structure:start -->") {
+		t.Error("expected structure to be wrapped with markers")
+	}
+}
+
+func TestLoader_LanguageFallback(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	writeTemplate(t, ProjectDir(root), "company.md.tmpl", "english\n")
+	writeTemplate(t, ProjectDir(root), "company_ja.md.tmpl", "japanese\n")
+	writeTemplate(t, ProjectDir(root), "oss.md.tmpl", "project english\n")
+
+	loader := NewLoader(root)
+
+	tests := []struct {
+		name string
+		lang i18n.Language
+		want string
+	}{
+		{"company", i18n.English, "english\n"},
+		{"company", i18n.Japanese, "japanese\n"},
+		// The project template overrides the built-in one, including its
+		// Japanese variant, and falls back to its own English file
+		{"oss", i18n.Japanese, "project english\n"},
+	}
+	for _, tt := range tests {
+		content, src, err := loader.Load(tt.name, tt.lang)
+		if err != nil {
+			t.Fatalf("Load(%s, %s) error = %v", tt.name, tt.lang, err)
+		}
+		if content != tt.want {
+			t.Errorf("Load(%s, %s) = %q, want %q", tt.name, tt.lang, content, tt.want)
+		}
+		if src.Kind != SourceProject {
+			t.Errorf("Load(%s, %s) source = %s, want %s", tt.name, tt.lang, src.Kind, SourceProject)
+		}
+	}
+}
+
+func TestLoader_List(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	writeTemplate(t, ProjectDir(root), "company_ja.md.tmpl", "ja\n")
+	writeTemplate(t, ProjectDir(root), "company.md.tmpl", "en\n")
+	writeTemplate(t, ProjectDir(root), "general.md.tmpl", "en\n")
+
+	var got []string
+	for _, info := range NewLoader(root).List() {
+		var langs []string
+		for _, lang := range info.Langs {
+			langs = append(langs, string(lang))
+		}
+		got = append(got, info.Name+":"+info.Source.Kind+":"+strings.Join(langs, ","))
+	}
+
+	want := "cli:builtin:en,ja company:project:en,ja general:project:en library:builtin:en,ja monorepo:builtin:en,ja oss:builtin:en,ja service:builtin:en,ja"
+	if strings.Join(got, " ") != want {
+		t.Errorf("List() = %v, want %s", got, want)
+	}
+}
+
+func TestLoader_InvalidName(t *testing.T) {
+	loader := NewLoader(t.TempDir())
+	for _, name := range []string{"", "../oss", ".hidden", `a\b`} {
+		if _, _, err := loader.Load(name, i18n.English); err == nil {
+			t.Errorf("Load(%q) should fail", name)
+		}
+	}
+}
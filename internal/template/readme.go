@@ -0,0 +1,73 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hulk510/readme-gen/internal/i18n"
+)
+
+// ReadmeTemplateFile is the project file that README.md is generated from
+const ReadmeTemplateFile = "README.md" + readmeTemplateExt
+
+// readmeTemplateExt is appended to a README name to get its template
+const readmeTemplateExt = ".tmpl"
+
+// ReadmeTemplate is a README template checked into the project
+type ReadmeTemplate struct {
+	// Path is the template file relative to the project root (e.g. "README.ja.md.tmpl")
+	Path string
+	// Output is the README rendered from it (e.g. "README.ja.md")
+	Output string
+	// Lang is the language of the README, taken from the file name
+	Lang i18n.Language
+}
+
+// FindReadmeTemplates returns the README templates in root, sorted by path.
+// README.md.tmpl renders README.md in English; README.<lang>.md.tmpl renders
+// the localized README.<lang>.md.
+func FindReadmeTemplates(root string) ([]ReadmeTemplate, error) {
+	files, err := filepath.Glob(filepath.Join(root, "README*.md"+readmeTemplateExt))
+	if err != nil {
+		return nil, err
+	}
+
+	var readmes []ReadmeTemplate
+	for _, file := range files {
+		if info, err := os.Stat(file); err != nil || info.IsDir() {
+			continue
+		}
+		path := filepath.Base(file)
+		output := strings.TrimSuffix(path, readmeTemplateExt)
+
+		lang, ok := ReadmeLanguage(output)
+		if !ok {
+			continue
+		}
+
+		readmes = append(readmes, ReadmeTemplate{Path: path, Output: output, Lang: lang})
+	}
+
+	sort.Slice(readmes, func(i, j int) bool { return readmes[i].Path < readmes[j].Path })
+	return readmes, nil
+}
+
+// ReadmeLanguage returns the language of a README from its file name:
+// English for README.md, <lang> for README.<lang>.md. It reports false for
+// other names such as README-old.md.
+func ReadmeLanguage(name string) (i18n.Language, bool) {
+	middle, ok := strings.CutSuffix(strings.TrimPrefix(name, "README"), ".md")
+	if !ok || !strings.HasPrefix(name, "README") {
+		return "", false
+	}
+	if middle == "" {
+		return i18n.English, true
+	}
+	code, ok := strings.CutPrefix(middle, ".")
+	if !ok || code == "" {
+		return "", false
+	}
+	return i18n.Language(code), true
+}
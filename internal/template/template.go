@@ -1,16 +1,13 @@
 package template
 
 import (
-	"bytes"
 	"embed"
-	"fmt"
-	"text/template"
 
 	"github.com/hulk510/readme-gen/internal/i18n"
-	"github.com/hulk510/readme-gen/internal/marker"
+	"github.com/hulk510/readme-gen/internal/scanner"
 )
 
-//go:embed templates/*.tmpl
+//go:embed templates/*.tmpl templates/partials/*.tmpl
 var templatesFS embed.FS
 
 // Data contains the data for rendering templates
@@ -20,44 +17,80 @@ type Data struct {
 	Structure   string
 	Language    string
 	ModulePath  string
+	// Version is the version declared in the project manifest
+	Version string
+	// Ecosystem is the package ecosystem (go, npm, cargo, ...), which selects
+	// the install commands. When empty it is derived from Language.
+	Ecosystem string
+	// Languages lists the languages of the source files, most used first
+	Languages []string
+	// Ecosystems lists every detected manifest, primary first
+	Ecosystems []scanner.Manifest
+	// License is the SPDX identifier of the project license and LicenseFile
+	// the file it was read from
+	License     string
+	LicenseFile string
+	// RepoURL is the web URL of the repository and DefaultBranch its default branch
+	RepoURL       string
+	DefaultBranch string
+	// LatestTag is the highest version tag of the repository
+	LatestTag string
+	// GoVersion and NodeVersion are the toolchain versions the project requires
+	GoVersion   string
+	NodeVersion string
 	Lang        i18n.Language
+	// Tasks lists the development commands of mise, make, task, just and package.json
+	Tasks []scanner.Task
+	// Binaries lists the commands of a Go module (main packages such as cmd/<name>)
+	Binaries []scanner.Binary
+	// Packages lists the nested packages of a monorepo
+	Packages []scanner.Package
 }
 
-// Render renders a template with the given data
-func Render(templateName string, data Data) (string, error) {
-	// Wrap structure with markers
-	data.Structure = marker.Wrap(data.Structure)
-
-	// Determine template file based on language
-	tmplFile := fmt.Sprintf("templates/%s.md.tmpl", templateName)
-	if data.Lang == i18n.Japanese {
-		tmplFile = fmt.Sprintf("templates/%s_ja.md.tmpl", templateName)
+// WithEcosystem returns a copy of d describing the project as seen from one of
+// its manifests, so partials such as "install" can be rendered per ecosystem:
+//
+//	{{range .Ecosystems}}{{template "install" ($.WithEcosystem .)}}{{end}}
+func (d Data) WithEcosystem(m scanner.Manifest) Data {
+	d.Ecosystem = m.Ecosystem
+	d.Language = m.Language
+	d.ModulePath = m.ModulePath
+	d.Version = m.Version
+	if m.Name != "" {
+		d.ProjectName = m.Name
 	}
+	return d
+}
 
-	// Load template
-	tmplContent, err := templatesFS.ReadFile(tmplFile)
-	if err != nil {
-		// Fallback to English template if Japanese not found
-		tmplContent, err = templatesFS.ReadFile(fmt.Sprintf("templates/%s.md.tmpl", templateName))
-		if err != nil {
-			return "", fmt.Errorf("template '%s' not found", templateName)
-		}
+// Commands returns the names of the commands the project installs: its Go
+// binaries, or else the project name
+func (d Data) Commands() []string {
+	if len(d.Binaries) == 0 {
+		return []string{d.ProjectName}
 	}
-
-	// Parse and execute template
-	tmpl, err := template.New(templateName).Parse(string(tmplContent))
-	if err != nil {
-		return "", fmt.Errorf("failed to parse template: %w", err)
+	names := make([]string, len(d.Binaries))
+	for i, b := range d.Binaries {
+		names[i] = b.Name
 	}
+	return names
+}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return "", fmt.Errorf("failed to execute template: %w", err)
-	}
+// Render renders a built-in template with the given data.
+// Use NewLoader to include project and user templates.
+func Render(templateName string, data Data) (string, error) {
+	return builtinLoader.Render(templateName, data)
+}
 
-	return buf.String(), nil
+// defaultEcosystems maps languages to the ecosystem assumed when Data.Ecosystem is empty
+var defaultEcosystems = map[string]string{
+	"go":         scanner.EcosystemGo,
+	"typescript": scanner.EcosystemNpm,
+	"javascript": scanner.EcosystemNpm,
 }
 
+// builtinLoader only knows the embedded templates
+var builtinLoader = &Loader{root: ".", sources: []Source{builtinSource()}}
+
 // GetClaudeSkills returns the Claude Code skills content
 func GetClaudeSkills() string {
 	return `---
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hulk510/readme-gen/internal/i18n"
+	"github.com/hulk510/readme-gen/internal/scanner"
 )
 
 func TestRender_OSS(t *testing.T) {
@@ -160,3 +161,256 @@ func TestGetClaudeSkills(t *testing.T) {
 		t.Error("expected skills to contain 'user_invocable: true'")
 	}
 }
+
+func TestRender_KindTemplates(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     i18n.Language
+		language string
+		want     []string
+	}{
+		{"cli", i18n.English, "go", []string{"go install github.com/example/tool@latest", "tool --help", "## Commands"}},
+		{"cli", i18n.Japanese, "typescript", []string{"npm install -g tool", "## コマンド"}},
+		{"library", i18n.English, "go", []string{"go get github.com/example/tool", "import \"github.com/example/tool\"", "https://pkg.go.dev/github.com/example/tool"}},
+		{"library", i18n.Japanese, "typescript", []string{"import {} from \"tool\";", "https://www.npmjs.com/package/tool"}},
+		{"service", i18n.English, "go", []string{"## Running Locally", "## Configuration", "docker build -t tool ."}},
+		{"service", i18n.Japanese, "typescript", []string{"## ローカルでの起動", "npm run dev"}},
+		{"monorepo", i18n.English, "go", []string{"## Packages", "| [core](packages/core) | Core logic |", "go work sync"}},
+		{"monorepo", i18n.Japanese, "typescript", []string{"## パッケージ", "| [core](packages/core) | Core logic |"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"_"+string(tt.lang), func(t *testing.T) {
+			data := Data{
+				ProjectName: "tool",
+				Structure:   "cmd/",
+				Language:    tt.language,
+				ModulePath:  "github.com/example/tool",
+				Lang:        tt.lang,
+				Packages:    []scanner.Package{{Path: "packages/core", Name: "core", Description: "Core logic"}},
+			}
+			result, err := Render(tt.name, data)
+			if err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(result, want) {
+					t.Errorf("expected %s template to contain %q, got:\n%s", tt.name, want, result)
+				}
+			}
+			if !strings.Contains(result, "<!-- readme-gen:structure:start -->") {
+				t.Errorf("expected %s template to contain structure markers", tt.name)
+			}
+		})
+	}
+}
+
+func TestRender_EcosystemInstall(t *testing.T) {
+	tests := []struct {
+		name       string
+		ecosystem  string
+		modulePath string
+		want       string
+	}{
+		{"oss", "cargo", "", "cargo install tool"},
+		{"oss", "pypi", "", "pip install tool"},
+		{"oss", "deno", "", "deno add jsr:tool"},
+		{"oss", "maven", "com.example:tool", "<groupId>com.example</groupId>\n  <artifactId>tool</artifactId>\n  <version>1.2.0</version>"},
+		{"oss", "gradle", "com.example:tool", `implementation("com.example:tool:1.2.0")`},
+		{"oss", "composer", "acme/tool", "composer require acme/tool"},
+		{"oss", "rubygems", "", "gem install tool"},
+		{"oss", "nuget", "", "dotnet add package tool"},
+		{"cli", "cargo", "", "cargo install tool"},
+		{"library", "cargo", "", "cargo add tool"},
+		{"library", "pypi", "", "pip install tool"},
+		{"general", "cargo", "", "cd tool\ncargo build\n"},
+		{"general", "pypi", "", "pip install -e ."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"_"+tt.ecosystem, func(t *testing.T) {
+			result, err := Render(tt.name, Data{
+				ProjectName: "tool",
+				ModulePath:  tt.modulePath,
+				Version:     "1.2.0",
+				Ecosystem:   tt.ecosystem,
+				Lang:        i18n.English,
+			})
+			if err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			if !strings.Contains(result, tt.want) {
+				t.Errorf("expected %s template to contain %q, got:\n%s", tt.name, tt.want, result)
+			}
+		})
+	}
+}
+
+func TestRender_PerEcosystemInstall(t *testing.T) {
+	data := Data{
+		ProjectName: "tool",
+		ModulePath:  "github.com/example/tool",
+		Language:    "go",
+		Ecosystem:   "go",
+		Ecosystems: []scanner.Manifest{
+			{Ecosystem: "go", Language: "go", Name: "tool", ModulePath: "github.com/example/tool"},
+			{Ecosystem: "npm", Language: "javascript", Name: "tool-web"},
+		},
+		Lang: i18n.English,
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"oss", "### Go\n\n```bash\ngo install github.com/example/tool@latest\n```\n\n### npm\n\n```bash\nnpm install tool-web\n"},
+		{"library", "### Go\n\n```bash\ngo get github.com/example/tool\n```\n\n### npm\n\n```bash\nnpm install tool-web\n```"},
+		{"general", "cd tool\ngo mod download\nbun install\n```"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Render(tt.name, data)
+			if err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			if !strings.Contains(result, tt.want) {
+				t.Errorf("expected %s template to contain %q, got:\n%s", tt.name, tt.want, result)
+			}
+		})
+	}
+}
+
+func TestRender_ProjectMetadata(t *testing.T) {
+	data := Data{
+		ProjectName: "tool",
+		Language:    "go",
+		License:     "Apache-2.0",
+		LicenseFile: "LICENSE",
+		RepoURL:     "https://github.com/example/tool",
+		GoVersion:   "1.22",
+	}
+
+	tests := []struct {
+		name string
+		lang i18n.Language
+		want []string
+	}{
+		{"oss", i18n.English, []string{"Apache-2.0 License - see [LICENSE](LICENSE) for details."}},
+		{"oss", i18n.Japanese, []string{"Apache-2.0 License - 詳細は [LICENSE](LICENSE) を参照してください。"}},
+		{"general", i18n.English, []string{"- Go 1.22+", "git clone https://github.com/example/tool"}},
+		{"cli", i18n.English, []string{"Apache-2.0 License - see [LICENSE](LICENSE)"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"_"+string(tt.lang), func(t *testing.T) {
+			data.Lang = tt.lang
+			result, err := Render(tt.name, data)
+			if err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(result, want) {
+					t.Errorf("expected %s template to contain %q, got:\n%s", tt.name, want, result)
+				}
+			}
+			if strings.Contains(result, "MIT") {
+				t.Errorf("expected %s template not to mention MIT, got:\n%s", tt.name, result)
+			}
+		})
+	}
+
+	// An unrecognized license file is linked without a name
+	data.License, data.LicenseFile, data.Lang = "", "COPYING", i18n.English
+	result, err := Render("oss", data)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(result, "See [COPYING](COPYING) for details.") {
+		t.Errorf("expected link to COPYING, got:\n%s", result)
+	}
+}
+
+func TestRender_NoLicenseFile(t *testing.T) {
+	tests := []struct {
+		lang i18n.Language
+		want string
+	}{
+		{i18n.English, "## License\n\nSee [LICENSE](LICENSE) for details."},
+		{i18n.Japanese, "詳細は [LICENSE](LICENSE) を参照してください。"},
+	}
+	for _, tt := range tests {
+		for _, name := range []string{"oss", "cli", "library", "service", "monorepo"} {
+			result, err := Render(name, Data{ProjectName: "tool", Language: "go", Lang: tt.lang})
+			if err != nil {
+				t.Fatalf("Render(%s) failed: %v", name, err)
+			}
+			if !strings.Contains(result, tt.want) || strings.Contains(result, "MIT") {
+				t.Errorf("expected %s_%s to render the neutral license notice, got:\n%s", name, tt.lang, result)
+			}
+		}
+	}
+}
+
+func TestRender_Tasks(t *testing.T) {
+	data := Data{ProjectName: "tool", Language: "go", Lang: i18n.English}
+	result, err := Render("general", data)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(result, "# Run tests\ngo test ./...") || strings.Contains(result, "readme-gen:tasks") {
+		t.Errorf("expected default development commands without tasks, got:\n%s", result)
+	}
+
+	// With task runners the development section becomes a tasks section
+	data.Tasks = []scanner.Task{{Runner: scanner.RunnerMise, Name: "test", Command: "mise run test"}}
+	for _, name := range []string{"general", "monorepo"} {
+		result, err := Render(name, data)
+		if err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+		if !strings.Contains(result, "<!-- readme-gen:tasks:start -->\n") || !strings.Contains(result, "<!-- readme-gen:tasks:end -->") {
+			t.Errorf("expected %s template to contain tasks markers, got:\n%s", name, result)
+		}
+		if name == "general" && strings.Contains(result, "# Run tests") {
+			t.Errorf("expected tasks to replace the default commands, got:\n%s", result)
+		}
+	}
+}
+
+func TestRender_Binaries(t *testing.T) {
+	data := Data{
+		ProjectName: "tool",
+		Language:    "go",
+		ModulePath:  "example.com/tool",
+		Lang:        i18n.English,
+		Binaries: []scanner.Binary{
+			{Name: "tool", Path: "./cmd/tool", ImportPath: "example.com/tool/cmd/tool"},
+			{Name: "toold", Path: "./cmd/toold", ImportPath: "example.com/tool/cmd/toold"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"oss", []string{"go install example.com/tool/cmd/tool@latest\ngo install example.com/tool/cmd/toold@latest\n```", "tool [command]\ntoold [command]\n```"}},
+		{"cli", []string{"### toold\n\n```bash\ntoold [command] [flags]\n\n# Show help for any command\ntoold --help\n```"}},
+		{"general", []string{"go run ./cmd/tool\n"}},
+		{"service", []string{"go run ./cmd/tool\n"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Render(tt.name, data)
+			if err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(result, want) {
+					t.Errorf("expected %s template to contain %q, got:\n%s", tt.name, want, result)
+				}
+			}
+			if strings.Contains(result, "go install example.com/tool@latest") {
+				t.Errorf("expected %s template not to install the module root, got:\n%s", tt.name, result)
+			}
+		})
+	}
+}